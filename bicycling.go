@@ -0,0 +1,69 @@
+package amap
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// BicyclingRequest 骑行路径规划请求参数
+type BicyclingRequest struct {
+	Origin      string // 起点坐标，必填 "经度,纬度"
+	Destination string // 终点坐标，必填 "经度,纬度"
+}
+
+// Bicycling 骑行路径规划 - 规划 500 公里以内的骑行路线
+// 该接口为 v4 版本，响应中的 errcode/errmsg 会转换为与其他接口一致的 APIError
+// https://lbs.amap.com/api/webservice/guide/api/direction
+func (c *Client) Bicycling(req *BicyclingRequest) (*RouteResponse, error) {
+	return c.BicyclingContext(context.Background(), req)
+}
+
+// BicyclingContext 同 Bicycling，支持通过 context 控制取消与超时
+func (c *Client) BicyclingContext(ctx context.Context, req *BicyclingRequest) (*RouteResponse, error) {
+	if err := checkOriginDestination(req.Origin, req.Destination); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("origin", req.Origin)
+	params.Set("destination", req.Destination)
+
+	return c.doRouteRequest(ctx, "v4/direction/bicycling", params, req)
+}
+
+// ElectroBikeRequest 电动车路径规划请求参数
+type ElectroBikeRequest struct {
+	Origin           string   // 起点坐标，必填 "经度,纬度"
+	Destination      string   // 终点坐标，必填 "经度,纬度"
+	AlternativeRoute int      // 返回方案条数，可选，取值 1-3
+	ShowFields       []string // 扩展字段，可选，见 ShowField* 常量
+}
+
+// ElectroBike 电动车路径规划 - 规划电动车骑行路线，会避开禁行道路
+// https://lbs.amap.com/api/webservice/guide/api/newroute
+func (c *Client) ElectroBike(req *ElectroBikeRequest) (*RouteResponse, error) {
+	return c.ElectroBikeContext(context.Background(), req)
+}
+
+// ElectroBikeContext 同 ElectroBike，支持通过 context 控制取消与超时
+func (c *Client) ElectroBikeContext(ctx context.Context, req *ElectroBikeRequest) (*RouteResponse, error) {
+	if err := checkOriginDestination(req.Origin, req.Destination); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("origin", req.Origin)
+	params.Set("destination", req.Destination)
+
+	if req.AlternativeRoute > 0 {
+		params.Set("alternative_route", strconv.Itoa(req.AlternativeRoute))
+	}
+
+	if len(req.ShowFields) > 0 {
+		params.Set("show_fields", strings.Join(req.ShowFields, ","))
+	}
+
+	return c.doRouteRequest(ctx, "v5/direction/electrobike", params, req)
+}
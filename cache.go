@@ -1,6 +1,7 @@
 package amap
 
 import (
+	"context"
 	"crypto/md5"
 	"encoding/json"
 	"fmt"
@@ -17,6 +18,15 @@ type Cache interface {
 	Set(key string, value []byte)
 }
 
+// ContextCache 支持 context 的缓存接口，可选实现
+// 缓存实现了该接口时，客户端会优先使用它，以便在 context 取消时及时中止等待
+type ContextCache interface {
+	// GetContext 获取缓存值
+	GetContext(ctx context.Context, key string) ([]byte, bool)
+	// SetContext 设置缓存值
+	SetContext(ctx context.Context, key string, value []byte)
+}
+
 // TTLMapCache 基于TTL Map的缓存实现
 type TTLMapCache struct {
 	ttl    time.Duration
@@ -53,3 +63,41 @@ func generateCacheKey(prefix string, params interface{}) string {
 	hash := md5.Sum(data)
 	return fmt.Sprintf("%s:%x", prefix, hash)
 }
+
+// cacheGet 读取缓存，等待期间响应 context 取消
+func cacheGet(ctx context.Context, cache Cache, key string) ([]byte, bool, error) {
+	if cc, ok := cache.(ContextCache); ok {
+		data, found := cc.GetContext(ctx, key)
+		return data, found, ctx.Err()
+	}
+	// 不可取消的 context 无需额外的协程
+	if ctx.Done() == nil {
+		data, found := cache.Get(key)
+		return data, found, nil
+	}
+
+	type result struct {
+		data  []byte
+		found bool
+	}
+	ch := make(chan result, 1)
+	go func() {
+		data, found := cache.Get(key)
+		ch <- result{data: data, found: found}
+	}()
+	select {
+	case <-ctx.Done():
+		return nil, false, ctx.Err()
+	case r := <-ch:
+		return r.data, r.found, nil
+	}
+}
+
+// cacheSet 写入缓存
+func cacheSet(ctx context.Context, cache Cache, key string, value []byte) {
+	if cc, ok := cache.(ContextCache); ok {
+		cc.SetContext(ctx, key, value)
+		return
+	}
+	cache.Set(key, value)
+}
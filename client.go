@@ -1,10 +1,15 @@
 package amap
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -17,37 +22,72 @@ const (
 
 // Client 高德地图API客户端
 type Client struct {
-	APIKey     string
-	HTTPClient *http.Client
-	BaseURL    string
-	Cache      Cache // 可选缓存接口
+	APIKey      string
+	HTTPClient  *http.Client
+	BaseURL     string
+	Cache       Cache        // 可选缓存接口
+	Retry       *RetryPolicy // 可选重试策略，为空时不重试
+	Limiter     *RateLimiter // 可选客户端限流器
+	Secret      string       // 可选数字签名私钥，设置后每个请求都会附带 sig 参数
+	Keys        *KeyPool     // 可选 Key 池，设置后忽略 APIKey
+	UserAgent   string       // 可选请求头 User-Agent
+	Logger      *slog.Logger // 可选日志，为空时不输出
+	Middlewares []Middleware // 可选中间件，按添加顺序由外到内执行
+	Metrics     Metrics      // 可选指标收集器，为空时不记录
+	Tracer      Tracer       // 可选链路追踪，为空时不记录
+	TipsCache   Cache        // 可选输入提示专用缓存，适合较短的过期时间，为空时使用 Cache
+
+	httpOpts httpOptions // New 中收集的 HTTP 客户端配置，所有 Option 执行后应用
 }
 
-// NewClient 创建新的高德地图API客户端
-func NewClient(apiKey string) *Client {
-	return &Client{
+// httpOptions WithTimeout 与 WithTransport 收集的配置
+type httpOptions struct {
+	timeout   *time.Duration
+	transport http.RoundTripper
+}
+
+// New 创建高德地图API客户端
+// 默认 HTTP 超时 10 秒，复用连接，不启用缓存、重试与限流，可通过 Option 调整
+func New(apiKey string, opts ...Option) *Client {
+	c := &Client{
 		APIKey:  apiKey,
 		BaseURL: BaseURL,
 		HTTPClient: &http.Client{
 			Timeout: 10 * time.Second,
 			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
 				MaxIdleConns:    10,
 				IdleConnTimeout: time.Minute,
 			},
 		},
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// 在副本上应用超时与 Transport，不修改 WithHTTPClient 传入的客户端（如 http.DefaultClient）
+	if o := c.httpOpts; o.timeout != nil || o.transport != nil {
+		cli := *c.HTTPClient
+		if o.timeout != nil {
+			cli.Timeout = *o.timeout
+		}
+		if o.transport != nil {
+			cli.Transport = o.transport
+		}
+		c.HTTPClient = &cli
+	}
+	c.httpOpts = httpOptions{}
+	return c
+}
+
+// NewClient 创建新的高德地图API客户端，等同于 New(apiKey)
+func NewClient(apiKey string) *Client {
+	return New(apiKey)
 }
 
-// NewClientWithCache 创建带缓存的高德地图API客户端
+// NewClientWithCache 创建带缓存的高德地图API客户端，等同于 New(apiKey, WithCache(cache))
 func NewClientWithCache(apiKey string, cache Cache) *Client {
-	return &Client{
-		APIKey:  apiKey,
-		BaseURL: BaseURL,
-		Cache:   cache,
-		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
+	return New(apiKey, WithCache(cache))
 }
 
 // SetHTTPClient 设置 HTTP 客户端
@@ -61,63 +101,322 @@ func (c *Client) SetCache(cache Cache) {
 	c.Cache = cache
 }
 
+// SetTipsCache 设置输入提示专用缓存
+func (c *Client) SetTipsCache(cache Cache) {
+	c.TipsCache = cache
+}
+
+// SetSecret 设置数字签名私钥，Key 开启数字签名校验时必须设置
+func (c *Client) SetSecret(secret string) {
+	c.Secret = secret
+}
+
+// SetKeyPool 设置多 Key 池，设置后按池中的 Key 轮询请求
+func (c *Client) SetKeyPool(pool *KeyPool) {
+	c.Keys = pool
+}
+
+// SetRetryPolicy 设置重试策略，传入 nil 关闭重试
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.Retry = policy
+}
+
+// SetRateLimiter 设置客户端限流器，传入 nil 关闭限流
+func (c *Client) SetRateLimiter(limiter *RateLimiter) {
+	c.Limiter = limiter
+}
+
+// SetLogger 设置日志，传入 nil 关闭日志
+func (c *Client) SetLogger(logger *slog.Logger) {
+	c.Logger = logger
+}
+
+// Use 添加中间件，先添加的中间件位于外层
+func (c *Client) Use(mws ...Middleware) {
+	c.Middlewares = append(c.Middlewares, mws...)
+}
+
+// SetMetrics 设置指标收集器
+func (c *Client) SetMetrics(metrics Metrics) {
+	c.Metrics = metrics
+}
+
+// SetTracer 设置链路追踪
+func (c *Client) SetTracer(tracer Tracer) {
+	c.Tracer = tracer
+}
+
 // SetTimeout 设置HTTP请求超时时间
+// 在 HTTP 客户端的副本上修改，不影响共享的客户端（如 http.DefaultClient）
 func (c *Client) SetTimeout(timeout time.Duration) {
-	c.HTTPClient.Timeout = timeout
+	cli := *c.HTTPClient
+	cli.Timeout = timeout
+	c.HTTPClient = &cli
 }
 
-// doRequest 执行HTTP请求
-func (c *Client) doRequest(endpoint string, params url.Values) ([]byte, error) {
-	// 添加API Key
-	params.Set("key", c.APIKey)
+// doRequest 执行HTTP请求，按重试策略处理瞬时错误
+// 返回最后一次尝试的结果
+func (c *Client) doRequest(ctx context.Context, endpoint string, params url.Values) ([]byte, Attempt, error) {
+	var failovers int
+	for n := 1; ; {
+		key, secret, err := c.selectKey(endpoint)
+		if err != nil {
+			return nil, Attempt{Number: n}, err
+		}
 
-	// 构建完整URL
-	fullURL := fmt.Sprintf("%s/%s/%s?%s", c.BaseURL, APIVersion, endpoint, params.Encode())
+		// 每次尝试都会消耗高德的调用量，需经过限流；高德按 Key 计算 QPS 与日配额，使用 Key 池时按 Key 分别限流
+		if c.Limiter != nil {
+			if err := c.Limiter.wait(ctx, c.logger(), c.limiterKey(key), endpoint); err != nil {
+				// 当前 Key 的客户端日配额用尽时切换到其他 Key
+				if c.Keys != nil && errors.Is(err, ErrDailyQuotaExceeded) && c.Keys.exhaust(key, endpoint) {
+					if failovers < c.Keys.Len()-1 && c.Keys.available(endpoint) {
+						failovers++
+						continue
+					}
+				}
+				return nil, Attempt{Number: n}, err
+			}
+		}
 
-	// 发送GET请求
-	resp, err := c.HTTPClient.Get(fullURL)
-	if err != nil {
-		return nil, fmt.Errorf("http get err: %w", err)
+		req := Request{Endpoint: endpoint, Params: cloneParams(params), Attempt: n}
+		body, attempt := c.doAttempt(ctx, &req, key, secret)
+		if attempt.Err == nil {
+			return body, attempt, nil
+		}
+
+		if c.Limiter != nil && errors.Is(attempt.Err, ErrQuotaExceeded) {
+			c.Limiter.exhaust(c.limiterKey(key), endpoint)
+		}
+		if c.Keys != nil {
+			// 当前 Key 被隔离时立即切换到其他 Key，不计入重试次数
+			if c.Keys.report(key, endpoint, attempt.Err) {
+				c.logger().WarnContext(ctx, "amap key quarantined", "key", maskKey(key), "endpoint", endpoint, "infocode", attempt.InfoCode)
+				if failovers < c.Keys.Len()-1 && c.Keys.available(endpoint) {
+					failovers++
+					continue
+				}
+			}
+		}
+
+		if !c.Retry.shouldRetry(ctx, attempt) {
+			// 区分调用方 context 结束与 HTTP 客户端、单次尝试超时，前者不可重试
+			if ctx.Err() != nil {
+				return nil, attempt, &contextError{err: attempt.Err}
+			}
+			return nil, attempt, attempt.Err
+		}
+		c.logger().InfoContext(ctx, "amap request retry", "endpoint", endpoint, "attempt", n, "infocode", attempt.InfoCode, "err", attempt.Err)
+		if err := sleepContext(ctx, c.Retry.backoff(n)); err != nil {
+			return nil, attempt, err
+		}
+		n++
+	}
+}
+
+// selectKey 选择本次请求 endpoint 使用的 Key 与签名私钥
+func (c *Client) selectKey(endpoint string) (key, secret string, err error) {
+	if c.Keys == nil {
+		return c.APIKey, c.Secret, nil
+	}
+	key, secret, err = c.Keys.next(endpoint)
+	if secret == "" {
+		secret = c.Secret
 	}
-	defer resp.Body.Close()
+	return key, secret, err
+}
 
-	// 读取响应体
-	body, err := io.ReadAll(resp.Body)
+// limiterKey 返回限流器中 key 的计数维度，未使用 Key 池时为空，所有请求共用一组限流与配额
+func (c *Client) limiterKey(key string) string {
+	if c.Keys == nil {
+		return ""
+	}
+	return key
+}
+
+// signParams 复制请求参数，添加 API Key 并计算数字签名
+func signParams(params url.Values, key, secret string) url.Values {
+	out := cloneParams(params)
+	out.Set("key", key)
+	if secret != "" {
+		out.Set("sig", sign(out, secret))
+	}
+	return out
+}
+
+// cloneParams 复制请求参数
+func cloneParams(params url.Values) url.Values {
+	out := make(url.Values, len(params)+2)
+	for k, v := range params {
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
+
+// doAttempt 经过中间件执行单次请求
+func (c *Client) doAttempt(ctx context.Context, req *Request, key, secret string) ([]byte, Attempt) {
+	if timeout := c.Retry.attemptTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ctx, span := c.tracer().Start(ctx, "amap attempt")
+	defer span.End()
+
+	handler := c.send(key, secret)
+	for i := len(c.Middlewares) - 1; i >= 0; i-- {
+		handler = c.Middlewares[i](handler)
+	}
+
+	resp, err := handler(ctx, req)
+	attempt := Attempt{Number: req.Attempt, Err: err}
+	if resp != nil {
+		attempt.StatusCode = resp.StatusCode
+		attempt.InfoCode = resp.Base.InfoCode
+	}
+	// 中间件可能直接返回错误而没有响应，从错误中补全状态以便判断重试
+	var (
+		apiErr  *APIError
+		httpErr *HTTPError
+	)
+	if attempt.InfoCode == "" && errors.As(err, &apiErr) {
+		attempt.InfoCode = apiErr.InfoCode
+	}
+	if attempt.StatusCode == 0 && errors.As(err, &httpErr) {
+		attempt.StatusCode = httpErr.StatusCode
+	}
+
+	span.SetAttribute("amap.attempt", attempt.Number)
+	span.SetAttribute("http.status_code", attempt.StatusCode)
+	span.SetAttribute("amap.infocode", attempt.InfoCode)
 	if err != nil {
-		return nil, fmt.Errorf("read response body err: %w", err)
+		span.RecordError(err)
+		return nil, attempt
+	}
+	if resp == nil {
+		attempt.Err = errors.New("middleware returned nil response")
+		return nil, attempt
+	}
+	return resp.Body, attempt
+}
+
+// send 返回发送HTTP请求的 Handler，位于中间件链的最内层，负责签名并校验高德的响应状态
+func (c *Client) send(key, secret string) Handler {
+	return func(ctx context.Context, r *Request) (*Response, error) {
+		params := signParams(r.Params, key, secret)
+
+		// 构建完整URL
+		fullURL := fmt.Sprintf("%s/%s?%s", c.BaseURL, endpointPath(r.Endpoint), params.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
+		if err != nil {
+			return nil, fmt.Errorf("new request err: %w", redactURLError(err, fullURL, params))
+		}
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		if p, ok := c.Tracer.(Propagator); ok {
+			p.Inject(ctx, req.Header)
+		}
+
+		// 发送GET请求
+		start := time.Now()
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			c.metrics().ObserveRequest(r.Endpoint, 0, time.Since(start))
+			return nil, fmt.Errorf("http get err: %w", redactURLError(err, fullURL, params))
+		}
+		defer resp.Body.Close()
+
+		out := Response{StatusCode: resp.StatusCode}
+
+		// 读取响应体
+		out.Body, err = io.ReadAll(resp.Body)
+		c.metrics().ObserveRequest(r.Endpoint, resp.StatusCode, time.Since(start))
+		if err != nil {
+			return &out, fmt.Errorf("read response body err: %w", err)
+		}
+		c.logResponse(ctx, params, fullURL, &out)
+
+		// 检查HTTP状态码
+		if resp.StatusCode != http.StatusOK {
+			return &out, newHTTPError(resp.StatusCode, out.Body, r.Endpoint)
+		}
+
+		// 检查高德响应状态，失败的响应不返回给调用方，也不会被缓存
+		if out.Base, err = decodeBase(out.Body); err != nil {
+			return &out, fmt.Errorf("unmarshal response err: %w", err)
+		}
+		if !out.Base.IsSuccess() {
+			return &out, newAPIError(&out.Base, r.Endpoint, params)
+		}
+		return &out, nil
 	}
+}
 
-	// 检查HTTP状态码
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP状态码错误: %d, 响应: %s", resp.StatusCode, string(body))
+// endpointPath 返回接口路径，未指定版本的接口（如 "geocode/geo"）使用 APIVersion，
+// 其他版本的接口以版本号开头，如 "v5/direction/driving"
+func endpointPath(endpoint string) string {
+	if len(endpoint) > 2 && endpoint[0] == 'v' && endpoint[1] >= '0' && endpoint[1] <= '9' && strings.Contains(endpoint, "/") {
+		return endpoint
 	}
+	return APIVersion + "/" + endpoint
+}
 
-	return body, nil
+// doRequestWithCache 执行带缓存的HTTP请求，cacheKeyParams 为 nil 时不使用缓存
+func (c *Client) doRequestWithCache(ctx context.Context, endpoint string, params url.Values, cacheKeyParams interface{}) ([]byte, error) {
+	return c.doCall(ctx, c.Cache, endpoint, params, cacheKeyParams)
+}
+
+// doCall 使用指定缓存执行一次调用，并记录日志、指标与追踪
+func (c *Client) doCall(ctx context.Context, cache Cache, endpoint string, params url.Values, cacheKeyParams interface{}) ([]byte, error) {
+	call := callInfo{Endpoint: endpoint, Start: time.Now()}
+	ctx, span := c.tracer().Start(ctx, "amap "+endpoint)
+	data, err := c.fetch(ctx, cache, &call, params, cacheKeyParams)
+	endCallSpan(span, &call, err)
+	c.logCall(ctx, &call, err)
+	if err != nil {
+		c.metrics().ObserveError(endpoint, errorCode(err))
+	}
+	return data, err
 }
 
-// doRequestWithCache 执行带缓存的HTTP请求
-func (c *Client) doRequestWithCache(endpoint string, params url.Values, cacheKeyParams interface{}) ([]byte, error) {
+// fetch 优先从缓存读取，未命中时执行请求并写入缓存
+func (c *Client) fetch(ctx context.Context, cache Cache, call *callInfo, params url.Values, cacheKeyParams interface{}) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// 如果没有缓存，直接请求
-	if c.Cache == nil {
-		return c.doRequest(endpoint, params)
+	if cache == nil || cacheKeyParams == nil {
+		data, attempt, err := c.doRequest(ctx, call.Endpoint, params)
+		call.Attempt = attempt
+		return data, err
 	}
 
 	// 生成缓存键
-	cacheKey := generateCacheKey(endpoint, cacheKeyParams)
+	cacheKey := generateCacheKey(call.Endpoint, cacheKeyParams)
 
 	// 尝试从缓存获取
-	if cachedData, found := c.Cache.Get(cacheKey); found {
+	cachedData, found, err := cacheGet(ctx, cache, cacheKey)
+	if err != nil {
+		return nil, err
+	}
+	c.metrics().ObserveCache(call.Endpoint, found)
+	if found {
+		call.CacheHit = true
 		return cachedData, nil
 	}
 
 	// 缓存未命中，执行实际请求
-	data, err := c.doRequest(endpoint, params)
+	data, attempt, err := c.doRequest(ctx, call.Endpoint, params)
+	call.Attempt = attempt
 	if err != nil {
 		return nil, err
 	}
 
 	// 将结果存入缓存
-	c.Cache.Set(cacheKey, data)
+	cacheSet(ctx, cache, cacheKey, data)
 
 	return data, nil
 }
@@ -139,5 +438,35 @@ func (r *BaseResponse) GetError() error {
 	if r.IsSuccess() {
 		return nil
 	}
-	return fmt.Errorf("API错误: %s (代码: %s)", r.Info, r.InfoCode)
+	return &APIError{Status: r.Status, Info: r.Info, InfoCode: r.InfoCode}
+}
+
+// errcodeEnvelope v4 接口（如骑行路径规划）的响应状态，使用 errcode/errmsg 表示
+type errcodeEnvelope struct {
+	ErrCode *json.Number `json:"errcode"`
+	ErrMsg  string       `json:"errmsg"`
+}
+
+// base 将 errcode/errmsg 转换为 BaseResponse，errcode 为 0 时表示成功
+func (e *errcodeEnvelope) base() BaseResponse {
+	code := e.ErrCode.String()
+	if code == "0" {
+		return BaseResponse{Status: "1", Info: e.ErrMsg, InfoCode: "10000"}
+	}
+	return BaseResponse{Status: "0", Info: e.ErrMsg, InfoCode: code}
+}
+
+// decodeBase 解析响应状态，兼容 status/info 与 errcode/errmsg 两种格式
+func decodeBase(data []byte) (BaseResponse, error) {
+	var aux struct {
+		BaseResponse
+		errcodeEnvelope
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return BaseResponse{}, err
+	}
+	if aux.Status == "" && aux.ErrCode != nil {
+		return aux.errcodeEnvelope.base(), nil
+	}
+	return aux.BaseResponse, nil
 }
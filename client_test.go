@@ -1,9 +1,14 @@
 package amap
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 )
 
 const testAPIKey = "YOUR_TEST_API_KEY" // 请替换为你的测试API Key
@@ -16,6 +21,16 @@ func getTestClient() *Client {
 	return NewClient(apiKey)
 }
 
+// newMockClient 创建指向本地模拟服务的客户端
+func newMockClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	client := NewClient("test-key")
+	client.BaseURL = srv.URL
+	return client
+}
+
 func TestGeocoding(t *testing.T) {
 	client := getTestClient()
 
@@ -227,3 +242,141 @@ func TestRegeoJSONParsing(t *testing.T) {
 
 	t.Log("JSON解析测试通过")
 }
+
+func TestGeocodeContextCanceled(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GeocodeContext(ctx, &GeocodeRequest{Address: "北京市朝阳区阜通东大街6号"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望 context.DeadlineExceeded，实际为: %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("请求未能及时取消")
+	}
+}
+
+// blockingCache 模拟阻塞的缓存实现
+type blockingCache struct{}
+
+func (blockingCache) Get(string) ([]byte, bool) {
+	time.Sleep(5 * time.Second)
+	return nil, false
+}
+
+func (blockingCache) Set(string, []byte) {}
+
+func TestCacheWaitCanceled(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("缓存等待被取消后不应发起请求")
+	})
+	client.SetCache(blockingCache{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.IPContext(ctx, &IPRequest{IP: "114.247.50.2"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望 context.DeadlineExceeded，实际为: %v", err)
+	}
+}
+
+func TestRegeoContextWithCache(t *testing.T) {
+	var calls int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","regeocode":{"formatted_address":"北京市海淀区"}}`))
+	})
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	req := &RegeoRequest{Location: "116.310003,39.991957"}
+	for range 2 {
+		resp, err := client.RegeoContext(context.Background(), req)
+		if err != nil {
+			t.Fatalf("逆地理编码失败: %v", err)
+		}
+		if resp.Regeocode.FormattedAddress != "北京市海淀区" {
+			t.Fatalf("格式化地址错误: %s", resp.Regeocode.FormattedAddress)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("期望请求 1 次，实际 %d 次", calls)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	var userAgent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.UserAgent()
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000"}`))
+	}))
+	defer srv.Close()
+
+	cache := NewTTLMapCache(time.Minute)
+	client := New("test-key",
+		WithBaseURL(srv.URL),
+		WithTimeout(3*time.Second),
+		WithCache(cache),
+		WithRetry(DefaultRetryPolicy()),
+		WithUserAgent("amap-test"),
+	)
+	if client.HTTPClient.Timeout != 3*time.Second {
+		t.Errorf("超时设置错误: %v", client.HTTPClient.Timeout)
+	}
+	if client.Cache != cache || client.Retry == nil {
+		t.Error("配置项未生效")
+	}
+
+	if _, err := client.GetCurrentIP(); err != nil {
+		t.Fatal(err)
+	}
+	if userAgent != "amap-test" {
+		t.Errorf("User-Agent 错误: %s", userAgent)
+	}
+
+	// 旧的构造函数行为一致
+	a, b := NewClient("k"), NewClientWithCache("k", cache)
+	if a.HTTPClient.Timeout != b.HTTPClient.Timeout {
+		t.Errorf("构造函数超时不一致: %v != %v", a.HTTPClient.Timeout, b.HTTPClient.Timeout)
+	}
+}
+
+func TestNewHTTPOptionsDoNotMutateSharedClient(t *testing.T) {
+	shared := &http.Client{Timeout: time.Second}
+	transport := &http.Transport{}
+
+	// WithTransport 在 WithHTTPClient 之前也能生效
+	client := New("k", WithTransport(transport), WithHTTPClient(shared), WithTimeout(3*time.Second))
+	if client.HTTPClient == shared {
+		t.Fatal("不应直接修改传入的 HTTP 客户端")
+	}
+	if shared.Timeout != time.Second || shared.Transport != nil {
+		t.Errorf("传入的 HTTP 客户端被修改: %+v", shared)
+	}
+	if client.HTTPClient.Timeout != 3*time.Second || client.HTTPClient.Transport != transport {
+		t.Errorf("超时或 Transport 未生效: %+v", client.HTTPClient)
+	}
+
+	defaultTimeout := http.DefaultClient.Timeout
+	client = New("k", WithHTTPClient(http.DefaultClient), WithTimeout(5*time.Second))
+	client.SetTimeout(7 * time.Second)
+	if http.DefaultClient.Timeout != defaultTimeout {
+		t.Errorf("http.DefaultClient 超时被修改为 %v", http.DefaultClient.Timeout)
+	}
+	if client.HTTPClient.Timeout != 7*time.Second {
+		t.Errorf("SetTimeout 未生效: %v", client.HTTPClient.Timeout)
+	}
+
+	// 未设置超时与 Transport 时直接使用传入的客户端
+	if client = New("k", WithHTTPClient(shared)); client.HTTPClient != shared {
+		t.Error("应使用传入的 HTTP 客户端")
+	}
+}
@@ -0,0 +1,109 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+)
+
+// 坐标转换的原坐标系
+const (
+	CoordSysGPS      = "gps"      // GPS 坐标（WGS-84）
+	CoordSysMapbar   = "mapbar"   // 图吧坐标
+	CoordSysBaidu    = "baidu"    // 百度坐标（BD-09）
+	CoordSysAutonavi = "autonavi" // 高德坐标（GCJ-02），不做转换
+)
+
+// maxConvertLocations 单次坐标转换的坐标数量上限
+const maxConvertLocations = 40
+
+// ConvertResponse 坐标转换响应
+type ConvertResponse struct {
+	BaseResponse
+	Locations string `json:"locations"` // 转换后的坐标串，以 ";" 分隔
+}
+
+// ConvertCoordinates 坐标转换 - 将 GPS、图吧、百度坐标转换为高德坐标
+// 超过 40 个坐标时自动拆分为多次请求，返回的坐标顺序与 coords 一致
+// https://lbs.amap.com/api/webservice/guide/api/convert
+func (c *Client) ConvertCoordinates(coords []Coordinate, coordsys string) ([]Coordinate, error) {
+	return c.ConvertCoordinatesContext(context.Background(), coords, coordsys)
+}
+
+// ConvertCoordinatesContext 同 ConvertCoordinates，支持通过 context 控制取消与超时
+func (c *Client) ConvertCoordinatesContext(ctx context.Context, coords []Coordinate, coordsys string) ([]Coordinate, error) {
+	if len(coords) == 0 {
+		return nil, fmt.Errorf("%w: locations 不能为空", ErrInvalidParams)
+	}
+	if coordsys == "" {
+		return nil, fmt.Errorf("%w: coordsys 不能为空", ErrInvalidParams)
+	}
+
+	result := make([]Coordinate, 0, len(coords))
+	for chunk := range slices.Chunk(coords, maxConvertLocations) {
+		converted, err := c.convert(ctx, chunk, coordsys)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, converted...)
+	}
+	return result, nil
+}
+
+// RegeoFromCoordsys 将 GPS、图吧、百度坐标转换为高德坐标后逆地理编码，两步均使用缓存
+// req 中的 Location 会被转换后的坐标覆盖，其余参数原样使用，req 为 nil 时使用默认参数；
+// coordsys 为 CoordSysAutonavi 时不做转换
+func (c *Client) RegeoFromCoordsys(coord Coordinate, coordsys string, req *RegeoRequest) (*RegeoResponse, error) {
+	return c.RegeoFromCoordsysContext(context.Background(), coord, coordsys, req)
+}
+
+// RegeoFromCoordsysContext 同 RegeoFromCoordsys，支持通过 context 控制取消与超时
+func (c *Client) RegeoFromCoordsysContext(ctx context.Context, coord Coordinate, coordsys string, req *RegeoRequest) (*RegeoResponse, error) {
+	if coordsys != CoordSysAutonavi {
+		converted, err := c.ConvertCoordinatesContext(ctx, []Coordinate{coord}, coordsys)
+		if err != nil {
+			return nil, err
+		}
+		coord = converted[0]
+	}
+
+	var regeo RegeoRequest
+	if req != nil {
+		regeo = *req
+	}
+	regeo.Location = coord.String()
+	return c.RegeoContext(ctx, &regeo)
+}
+
+// convert 执行一次坐标转换请求
+func (c *Client) convert(ctx context.Context, coords []Coordinate, coordsys string) ([]Coordinate, error) {
+	params := url.Values{}
+	params.Set("locations", joinCoordinates(coords, "|"))
+	params.Set("coordsys", coordsys)
+
+	// 使用带缓存的请求，按批次缓存
+	body, err := c.doRequestWithCache(ctx, "assistant/coordinate/convert", params, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ConvertResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	converted, err := ParsePolyline(resp.Locations)
+	if err != nil {
+		return nil, err
+	}
+	if len(converted) != len(coords) {
+		return nil, fmt.Errorf("convert: 返回 %d 个坐标，请求 %d 个", len(converted), len(coords))
+	}
+	return converted, nil
+}
@@ -0,0 +1,87 @@
+package amap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestConvertCoordinates(t *testing.T) {
+	var calls int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/assistant/coordinate/convert" || q.Get("coordsys") != CoordSysGPS {
+			t.Errorf("请求参数错误: %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		locations := strings.Split(q.Get("locations"), "|")
+		if len(locations) > maxConvertLocations {
+			t.Errorf("单次请求坐标数超过上限: %d", len(locations))
+		}
+		// 模拟偏移：经纬度各加 0.001
+		converted := make([]string, len(locations))
+		for i, l := range locations {
+			c, err := ParseCoordinate(l)
+			if err != nil {
+				t.Fatal(err)
+			}
+			converted[i] = Coordinate{Lng: c.Lng + 0.001, Lat: c.Lat + 0.001}.String()
+		}
+		fmt.Fprintf(w, `{"status":"1","info":"ok","infocode":"10000","locations":"%s"}`, strings.Join(converted, ";"))
+	})
+
+	coords := make([]Coordinate, 95)
+	for i := range coords {
+		coords[i] = Coordinate{Lng: 116 + float64(i)/1000, Lat: 39.9}
+	}
+	result, err := client.ConvertCoordinates(coords, CoordSysGPS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || len(result) != len(coords) {
+		t.Fatalf("拆分请求错误: calls=%d results=%d", calls, len(result))
+	}
+	for i, c := range result {
+		want := Coordinate{Lng: coords[i].Lng + 0.001, Lat: coords[i].Lat + 0.001}
+		if c.String() != want.String() {
+			t.Fatalf("第 %d 个坐标错误: got %v, want %v", i, c, want)
+		}
+	}
+
+	if _, err := client.ConvertCoordinates(nil, CoordSysGPS); !IsInvalidParams(err) {
+		t.Errorf("空坐标应返回参数错误，实际: %v", err)
+	}
+}
+
+func TestRegeoFromCoordsys(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/v3/assistant/coordinate/convert":
+			if q.Get("locations") != "116.481499,39.990475" || q.Get("coordsys") != CoordSysGPS {
+				t.Errorf("转换参数错误: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"status":"1","info":"ok","infocode":"10000","locations":"116.487585177952,39.991754014757"}`))
+		case "/v3/geocode/regeo":
+			if q.Get("location") != "116.487585,39.991754" || q.Get("extensions") != "all" {
+				t.Errorf("逆地理编码参数错误: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","regeocode":{"formatted_address":"北京市朝阳区望京街道","addressComponent":{"city":[],"district":"朝阳区","adcode":"110105"}}}`))
+		default:
+			t.Errorf("未预期的请求: %s", r.URL.Path)
+		}
+	})
+
+	req := &RegeoRequest{Location: "ignored", Extensions: "all"}
+	resp, err := client.RegeoFromCoordsys(Coordinate{Lng: 116.481499, Lat: 39.990475}, CoordSysGPS, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Regeocode.AddressComponent.AdCode != "110105" {
+		t.Errorf("结果错误: %+v", resp.Regeocode)
+	}
+	if req.Location != "ignored" {
+		t.Errorf("不应修改调用方的请求参数: %s", req.Location)
+	}
+}
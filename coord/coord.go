@@ -0,0 +1,107 @@
+// Package coord 提供 WGS-84（GPS）、GCJ-02（高德）与 BD-09（百度）坐标系之间的离线转换。
+// 所有函数的参数与返回值均为 (经度, 纬度)，中国大陆以外的坐标不做偏移。
+package coord
+
+import "math"
+
+const (
+	// 克拉索夫斯基椭球参数
+	semiMajorAxis = 6378245.0
+	eccentricity2 = 0.00669342162296594323
+
+	// bdFactor BD-09 加密使用的系数
+	bdFactor = math.Pi * 3000.0 / 180.0
+
+	// maxIterations GCJ-02 反算 WGS-84 的最大迭代次数
+	maxIterations = 30
+	// precision 反算的收敛精度（度），约 0.01 毫米
+	precision = 1e-10
+)
+
+// OutOfChina 判断坐标是否在中国大陆以外（粗略矩形范围），范围外不做 GCJ-02 偏移
+func OutOfChina(lng, lat float64) bool {
+	return lng < 72.004 || lng > 137.8347 || lat < 0.8293 || lat > 55.8271
+}
+
+// WGS84ToGCJ02 WGS-84 转 GCJ-02
+func WGS84ToGCJ02(lng, lat float64) (float64, float64) {
+	if OutOfChina(lng, lat) {
+		return lng, lat
+	}
+	dLng, dLat := delta(lng, lat)
+	return lng + dLng, lat + dLat
+}
+
+// GCJ02ToWGS84 GCJ-02 转 WGS-84
+// 通过迭代逼近精确反算，误差小于 1e-9 度
+func GCJ02ToWGS84(lng, lat float64) (float64, float64) {
+	if OutOfChina(lng, lat) {
+		return lng, lat
+	}
+	wgsLng, wgsLat := lng, lat
+	for range maxIterations {
+		gcjLng, gcjLat := WGS84ToGCJ02(wgsLng, wgsLat)
+		dLng, dLat := gcjLng-lng, gcjLat-lat
+		wgsLng, wgsLat = wgsLng-dLng, wgsLat-dLat
+		if math.Abs(dLng) < precision && math.Abs(dLat) < precision {
+			break
+		}
+	}
+	return wgsLng, wgsLat
+}
+
+// GCJ02ToBD09 GCJ-02 转 BD-09
+func GCJ02ToBD09(lng, lat float64) (float64, float64) {
+	z := math.Hypot(lng, lat) + 0.00002*math.Sin(lat*bdFactor)
+	theta := math.Atan2(lat, lng) + 0.000003*math.Cos(lng*bdFactor)
+	return z*math.Cos(theta) + 0.0065, z*math.Sin(theta) + 0.006
+}
+
+// BD09ToGCJ02 BD-09 转 GCJ-02
+// 使用通用的近似反算公式，误差约 0.1 米
+func BD09ToGCJ02(lng, lat float64) (float64, float64) {
+	x, y := lng-0.0065, lat-0.006
+	z := math.Hypot(x, y) - 0.00002*math.Sin(y*bdFactor)
+	theta := math.Atan2(y, x) - 0.000003*math.Cos(x*bdFactor)
+	return z * math.Cos(theta), z * math.Sin(theta)
+}
+
+// WGS84ToBD09 WGS-84 转 BD-09
+func WGS84ToBD09(lng, lat float64) (float64, float64) {
+	return GCJ02ToBD09(WGS84ToGCJ02(lng, lat))
+}
+
+// BD09ToWGS84 BD-09 转 WGS-84
+func BD09ToWGS84(lng, lat float64) (float64, float64) {
+	return GCJ02ToWGS84(BD09ToGCJ02(lng, lat))
+}
+
+// delta 计算 WGS-84 到 GCJ-02 的偏移量
+func delta(lng, lat float64) (float64, float64) {
+	dLng := transformLng(lng-105.0, lat-35.0)
+	dLat := transformLat(lng-105.0, lat-35.0)
+
+	radLat := lat / 180.0 * math.Pi
+	magic := 1 - eccentricity2*math.Sin(radLat)*math.Sin(radLat)
+	sqrtMagic := math.Sqrt(magic)
+
+	dLng = (dLng * 180.0) / (semiMajorAxis / sqrtMagic * math.Cos(radLat) * math.Pi)
+	dLat = (dLat * 180.0) / ((semiMajorAxis * (1 - eccentricity2)) / (magic * sqrtMagic) * math.Pi)
+	return dLng, dLat
+}
+
+func transformLat(x, y float64) float64 {
+	ret := -100.0 + 2.0*x + 3.0*y + 0.2*y*y + 0.1*x*y + 0.2*math.Sqrt(math.Abs(x))
+	ret += (20.0*math.Sin(6.0*x*math.Pi) + 20.0*math.Sin(2.0*x*math.Pi)) * 2.0 / 3.0
+	ret += (20.0*math.Sin(y*math.Pi) + 40.0*math.Sin(y/3.0*math.Pi)) * 2.0 / 3.0
+	ret += (160.0*math.Sin(y/12.0*math.Pi) + 320*math.Sin(y*math.Pi/30.0)) * 2.0 / 3.0
+	return ret
+}
+
+func transformLng(x, y float64) float64 {
+	ret := 300.0 + x + 2.0*y + 0.1*x*x + 0.1*x*y + 0.1*math.Sqrt(math.Abs(x))
+	ret += (20.0*math.Sin(6.0*x*math.Pi) + 20.0*math.Sin(2.0*x*math.Pi)) * 2.0 / 3.0
+	ret += (20.0*math.Sin(x*math.Pi) + 40.0*math.Sin(x/3.0*math.Pi)) * 2.0 / 3.0
+	ret += (150.0*math.Sin(x/12.0*math.Pi) + 300.0*math.Sin(x/30.0*math.Pi)) * 2.0 / 3.0
+	return ret
+}
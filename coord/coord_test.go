@@ -0,0 +1,101 @@
+package coord
+
+import (
+	"math"
+	"testing"
+)
+
+// distance 两点间的近似距离，单位：米
+func distance(lng1, lat1, lng2, lat2 float64) float64 {
+	const earthRadius = 6371000.0
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func TestWGS84ToGCJ02(t *testing.T) {
+	tests := []struct {
+		name             string
+		wgsLng, wgsLat   float64
+		wantLng, wantLat float64
+	}{
+		{"上海", 121.5272106, 31.1774276, 121.531541859215, 31.17530398364597},
+		{"深圳", 113.912316, 22.543847, 113.9171764808363, 22.540796131694766},
+		{"北京", 116.377817, 39.911954, 116.38404722455657, 39.91334545536069},
+		{"天安门", 116.404, 39.915, 116.41024449916938, 39.91640428150164},
+	}
+	for _, tt := range tests {
+		lng, lat := WGS84ToGCJ02(tt.wgsLng, tt.wgsLat)
+		if d := distance(lng, lat, tt.wantLng, tt.wantLat); d > 0.01 {
+			t.Errorf("%s: got (%v, %v), want (%v, %v), 误差 %.4f 米", tt.name, lng, lat, tt.wantLng, tt.wantLat, d)
+		}
+
+		// 反算应精确回到原始坐标
+		wgsLng, wgsLat := GCJ02ToWGS84(lng, lat)
+		if d := distance(wgsLng, wgsLat, tt.wgsLng, tt.wgsLat); d > 0.001 {
+			t.Errorf("%s: 反算误差 %.6f 米", tt.name, d)
+		}
+	}
+}
+
+func TestBD09(t *testing.T) {
+	lng, lat := GCJ02ToBD09(116.404, 39.915)
+	if d := distance(lng, lat, 116.41036949371029, 39.92133699351021); d > 0.01 {
+		t.Errorf("GCJ02ToBD09 误差 %.4f 米: (%v, %v)", d, lng, lat)
+	}
+	lng, lat = BD09ToGCJ02(116.404, 39.915)
+	if d := distance(lng, lat, 116.39762729119315, 39.90865673957631); d > 0.01 {
+		t.Errorf("BD09ToGCJ02 误差 %.4f 米: (%v, %v)", d, lng, lat)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// 覆盖中国大陆范围内的网格点
+	for lng := 75.0; lng <= 135; lng += 2.5 {
+		for lat := 18.0; lat <= 53; lat += 2.5 {
+			gcjLng, gcjLat := WGS84ToGCJ02(lng, lat)
+			if d := distance(lng, lat, gcjLng, gcjLat); d < 1 || d > 1000 {
+				t.Errorf("(%v, %v) GCJ-02 偏移 %.2f 米超出合理范围", lng, lat, d)
+			}
+			if wLng, wLat := GCJ02ToWGS84(gcjLng, gcjLat); distance(lng, lat, wLng, wLat) > 0.001 {
+				t.Errorf("(%v, %v) WGS-84 → GCJ-02 → WGS-84 误差过大: (%v, %v)", lng, lat, wLng, wLat)
+			}
+
+			bdLng, bdLat := WGS84ToBD09(lng, lat)
+			// BD-09 反算为近似公式，允许 0.5 米误差
+			if wLng, wLat := BD09ToWGS84(bdLng, bdLat); distance(lng, lat, wLng, wLat) > 0.5 {
+				t.Errorf("(%v, %v) WGS-84 → BD-09 → WGS-84 误差过大: (%v, %v)", lng, lat, wLng, wLat)
+			}
+		}
+	}
+}
+
+func TestOutOfChina(t *testing.T) {
+	tests := []struct {
+		name     string
+		lng, lat float64
+		want     bool
+	}{
+		{"北京", 116.404, 39.915, false},
+		{"伦敦", -0.1276, 51.5072, true},
+		{"东京", 139.6917, 35.6895, true},
+		{"悉尼", 151.2093, -33.8688, true},
+	}
+	for _, tt := range tests {
+		if got := OutOfChina(tt.lng, tt.lat); got != tt.want {
+			t.Errorf("%s: OutOfChina = %v, want %v", tt.name, got, tt.want)
+		}
+		if !tt.want {
+			continue
+		}
+		// 境外坐标原样返回
+		if lng, lat := WGS84ToGCJ02(tt.lng, tt.lat); lng != tt.lng || lat != tt.lat {
+			t.Errorf("%s: 境外坐标不应偏移，got (%v, %v)", tt.name, lng, lat)
+		}
+		if lng, lat := GCJ02ToWGS84(tt.lng, tt.lat); lng != tt.lng || lat != tt.lat {
+			t.Errorf("%s: 境外坐标不应偏移，got (%v, %v)", tt.name, lng, lat)
+		}
+	}
+}
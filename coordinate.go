@@ -0,0 +1,70 @@
+package amap
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Coordinate 经纬度坐标
+type Coordinate struct {
+	Lng float64 // 经度
+	Lat float64 // 纬度
+}
+
+// String 格式化为高德接口使用的 "经度,纬度"，保留 6 位小数
+func (c Coordinate) String() string {
+	return formatDegree(c.Lng) + "," + formatDegree(c.Lat)
+}
+
+// formatDegree 格式化经度或纬度，高德要求小数点后不超过 6 位
+func formatDegree(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+// ParseCoordinate 解析 "经度,纬度" 格式的坐标
+func ParseCoordinate(s string) (Coordinate, error) {
+	lngStr, latStr, ok := strings.Cut(strings.TrimSpace(s), ",")
+	if !ok {
+		return Coordinate{}, fmt.Errorf("invalid coordinate: %q", s)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return Coordinate{}, fmt.Errorf("invalid coordinate: %q", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return Coordinate{}, fmt.Errorf("invalid coordinate: %q", s)
+	}
+	return Coordinate{Lng: lng, Lat: lat}, nil
+}
+
+// joinCoordinates 以 sep 连接多个坐标
+func joinCoordinates(coords []Coordinate, sep string) string {
+	parts := make([]string, len(coords))
+	for i, c := range coords {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// ParsePolyline 解析 "经度,纬度;经度,纬度" 格式的坐标串
+func ParsePolyline(s string) ([]Coordinate, error) {
+	if s == "" {
+		return nil, nil
+	}
+	points := strings.Split(s, ";")
+	coords := make([]Coordinate, 0, len(points))
+	for _, p := range points {
+		if p == "" {
+			continue
+		}
+		c, err := ParseCoordinate(p)
+		if err != nil {
+			return nil, err
+		}
+		coords = append(coords, c)
+	}
+	return coords, nil
+}
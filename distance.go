@@ -0,0 +1,166 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 距离测量方式
+const (
+	DistanceStraight = 0 // 直线距离
+	DistanceDriving  = 1 // 驾车导航距离
+	DistanceWalking  = 3 // 步行导航距离，仅支持 5 公里内
+)
+
+// maxDistanceOrigins 单次距离测量的起点数量上限
+const maxDistanceOrigins = 100
+
+// DistanceRequest 距离测量请求参数
+type DistanceRequest struct {
+	Origins     []string // 起点坐标列表，必填 "经度,纬度"，最多 100 个
+	Destination string   // 终点坐标，必填 "经度,纬度"
+	Type        int      // 测量方式，见 Distance* 常量，零值为直线距离
+}
+
+// DistanceResponse 距离测量响应
+type DistanceResponse struct {
+	BaseResponse
+	Count   string           `json:"count"`   // 结果数目，与 Origins 数量一致
+	Results []DistanceResult `json:"results"` // 测量结果，与 Origins 一一对应
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况
+func (r *DistanceResponse) UnmarshalJSON(data []byte) error {
+	type alias DistanceResponse
+	return unmarshalLenient(data, (*alias)(r))
+}
+
+// DistanceResult 距离测量结果
+type DistanceResult struct {
+	OriginID int           // 起点序号，从 1 开始，为 0 表示未返回该起点的结果
+	Distance int           // 距离，单位：米
+	Duration time.Duration // 预计耗时，直线距离时为 0
+	Info     string        // 未规划出路线时的原因
+	Code     string        // 未规划出路线时的错误码
+}
+
+// UnmarshalJSON 解析数值字段
+func (r *DistanceResult) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		OriginID numString `json:"origin_id"`
+		Distance numString `json:"distance"`
+		Duration numString `json:"duration"`
+		Info     string    `json:"info"`
+		Code     string    `json:"code"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*r = DistanceResult{
+		OriginID: aux.OriginID.Int(),
+		Distance: aux.Distance.Int(),
+		Duration: time.Duration(aux.Duration.Int()) * time.Second,
+		Info:     aux.Info,
+		Code:     aux.Code,
+	}
+	return nil
+}
+
+// OK 是否测量成功
+func (r *DistanceResult) OK() bool {
+	return r.OriginID > 0 && r.Code == ""
+}
+
+// Distance 距离测量 - 测量多个起点到同一终点的直线、驾车或步行距离
+// 返回的 Results 与 Origins 按下标一一对应
+// https://lbs.amap.com/api/webservice/guide/api/direction
+func (c *Client) Distance(req *DistanceRequest) (*DistanceResponse, error) {
+	return c.DistanceContext(context.Background(), req)
+}
+
+// DistanceContext 同 Distance，支持通过 context 控制取消与超时
+func (c *Client) DistanceContext(ctx context.Context, req *DistanceRequest) (*DistanceResponse, error) {
+	if len(req.Origins) == 0 || req.Destination == "" {
+		return nil, fmt.Errorf("%w: origins 与 destination 不能为空", ErrInvalidParams)
+	}
+	if len(req.Origins) > maxDistanceOrigins {
+		return nil, fmt.Errorf("%w: origins 最多 %d 个，实际 %d 个，可使用 DistanceBatch", ErrInvalidParams, maxDistanceOrigins, len(req.Origins))
+	}
+
+	params := url.Values{}
+	params.Set("origins", strings.Join(req.Origins, "|"))
+	params.Set("destination", req.Destination)
+
+	// 接口缺省 type 时按驾车距离测量，始终显式传递以使零值对应直线距离
+	params.Set("type", strconv.Itoa(req.Type))
+
+	// 使用带缓存的请求
+	body, err := c.doRequestWithCache(ctx, "distance", params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DistanceResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	// 按 origin_id 对齐到 Origins 的下标
+	results := make([]DistanceResult, len(req.Origins))
+	for _, r := range resp.Results {
+		if r.OriginID >= 1 && r.OriginID <= len(results) {
+			results[r.OriginID-1] = r
+		}
+	}
+	resp.Results = results
+	resp.Count = strconv.Itoa(len(results))
+
+	return &resp, nil
+}
+
+// DistanceBatch 批量距离测量 - 起点超过 100 个时自动拆分为多次请求，结果按 Origins 顺序合并
+// 合并后结果的 OriginID 为其在 Origins 中的序号（从 1 开始）
+func (c *Client) DistanceBatch(req *DistanceRequest) (*DistanceResponse, error) {
+	return c.DistanceBatchContext(context.Background(), req)
+}
+
+// DistanceBatchContext 同 DistanceBatch，支持通过 context 控制取消与超时
+func (c *Client) DistanceBatchContext(ctx context.Context, req *DistanceRequest) (*DistanceResponse, error) {
+	if len(req.Origins) <= maxDistanceOrigins {
+		return c.DistanceContext(ctx, req)
+	}
+
+	resp := DistanceResponse{
+		BaseResponse: BaseResponse{Status: "1", Info: "OK", InfoCode: "10000"},
+		Results:      make([]DistanceResult, 0, len(req.Origins)),
+	}
+	for origins := range slices.Chunk(req.Origins, maxDistanceOrigins) {
+		chunk, err := c.DistanceContext(ctx, &DistanceRequest{
+			Origins:     origins,
+			Destination: req.Destination,
+			Type:        req.Type,
+		})
+		if err != nil {
+			return nil, err
+		}
+		offset := len(resp.Results)
+		for _, r := range chunk.Results {
+			if r.OriginID > 0 {
+				r.OriginID += offset
+			}
+			resp.Results = append(resp.Results, r)
+		}
+	}
+	resp.Count = strconv.Itoa(len(resp.Results))
+	return &resp, nil
+}
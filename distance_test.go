@@ -0,0 +1,83 @@
+package amap
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDistance(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/distance" || q.Get("type") != "1" || q.Get("destination") != "116.434446,39.90816" {
+			t.Errorf("请求参数错误: %s %s", r.URL.Path, r.URL.RawQuery)
+		}
+		// 结果乱序返回，第二个起点未规划出路线，第三个起点缺失
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","count":"2","results":[
+			{"origin_id":"2","dest_id":"1","distance":"0","duration":"0","info":"未规划出路线","code":"3"},
+			{"origin_id":"1","dest_id":"1","distance":"12420","duration":"1800"}]}`))
+	})
+
+	resp, err := client.Distance(&DistanceRequest{
+		Origins:     []string{"116.481028,39.989643", "116.1,39.1", "116.2,39.2"},
+		Destination: "116.434446,39.90816",
+		Type:        DistanceDriving,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Results) != 3 || resp.Count != "3" {
+		t.Fatalf("结果应与起点一一对应，实际 %d 个，count %s", len(resp.Results), resp.Count)
+	}
+	if r := resp.Results[0]; !r.OK() || r.Distance != 12420 || r.Duration != 30*time.Minute {
+		t.Errorf("第一个起点结果错误: %+v", r)
+	}
+	if r := resp.Results[1]; r.OK() || r.Info != "未规划出路线" {
+		t.Errorf("第二个起点应规划失败: %+v", r)
+	}
+	if r := resp.Results[2]; r.OK() || r.OriginID != 0 {
+		t.Errorf("第三个起点应缺失: %+v", r)
+	}
+
+	_, err = client.Distance(&DistanceRequest{Origins: make([]string, 101), Destination: "116.434446,39.90816"})
+	if !IsInvalidParams(err) {
+		t.Errorf("起点超过 100 个应返回参数错误，实际: %v", err)
+	}
+}
+
+func TestDistanceBatch(t *testing.T) {
+	var calls int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if typ := r.URL.Query().Get("type"); typ != "0" {
+			t.Errorf("直线距离应显式传递 type=0，实际: %q", typ)
+		}
+		origins := strings.Split(r.URL.Query().Get("origins"), "|")
+		results := make([]string, len(origins))
+		for i, o := range origins {
+			// 距离取起点经度，便于校验顺序
+			lng, _, _ := strings.Cut(o, ",")
+			results[i] = fmt.Sprintf(`{"origin_id":"%d","dest_id":"1","distance":"%s","duration":"0"}`, i+1, lng)
+		}
+		fmt.Fprintf(w, `{"status":"1","info":"OK","infocode":"10000","count":"%d","results":[%s]}`, len(results), strings.Join(results, ","))
+	})
+
+	origins := make([]string, 250)
+	for i := range origins {
+		origins[i] = fmt.Sprintf("%d,39.9", i+1)
+	}
+	resp, err := client.DistanceBatch(&DistanceRequest{Origins: origins, Destination: "116.434446,39.90816", Type: DistanceStraight})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || len(resp.Results) != 250 || resp.Count != "250" {
+		t.Fatalf("批量请求错误: calls=%d results=%d", calls, len(resp.Results))
+	}
+	for i, r := range resp.Results {
+		if r.OriginID != i+1 || r.Distance != i+1 {
+			t.Fatalf("第 %d 个结果顺序错误: %+v", i, r)
+		}
+	}
+}
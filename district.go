@@ -0,0 +1,146 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 行政区级别
+const (
+	DistrictLevelCountry  = "country"  // 国家
+	DistrictLevelProvince = "province" // 省份
+	DistrictLevelCity     = "city"     // 市
+	DistrictLevelDistrict = "district" // 区县
+	DistrictLevelStreet   = "street"   // 街道
+)
+
+// DistrictRequest 行政区域查询请求参数
+type DistrictRequest struct {
+	Keywords    string // 查询关键字，可选，支持行政区名称、citycode、adcode，为空时查询中国
+	Subdistrict int    // 返回下级行政区的级数，取值 0-3，默认 0 表示不返回
+	Page        int    // 当前页数，默认 1
+	Offset      int    // 最外层返回数据个数，默认 20
+	Extensions  string // 返回结果控制：base(默认) 或 all，all 时返回边界坐标
+	Filter      string // 按 adcode 过滤，仅返回该行政区，可选
+}
+
+// DistrictResponse 行政区域查询响应
+type DistrictResponse struct {
+	BaseResponse
+	Count      string     `json:"count"`      // 返回结果总数
+	Suggestion Suggestion `json:"suggestion"` // 建议结果
+	Districts  []District `json:"districts"`  // 行政区列表
+}
+
+// District 行政区信息，Districts 为下级行政区，构成 国家→省→市→区县→街道 的树
+type District struct {
+	CityCode  string     `json:"citycode"`  // 城市编码
+	AdCode    string     `json:"adcode"`    // 区域编码，街道没有独立的 adcode，与上级相同
+	Name      string     `json:"name"`      // 行政区名称
+	Polyline  string     `json:"polyline"`  // 原始边界坐标，extensions=all 时仅最外层返回
+	Center    string     `json:"center"`    // 中心点 "经度,纬度"
+	Level     string     `json:"level"`     // 行政区级别
+	Districts []District `json:"districts"` // 下级行政区
+
+	CenterCoord Coordinate     `json:"-"` // 解析后的中心点
+	Boundary    [][]Coordinate `json:"-"` // 解析后的边界，每个元素为一个闭合区域（如岛屿）
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况，并解析中心点与边界
+func (d *District) UnmarshalJSON(data []byte) error {
+	type alias District
+	if err := unmarshalLenient(data, (*alias)(d)); err != nil {
+		return err
+	}
+	if d.Center != "" {
+		center, err := ParseCoordinate(d.Center)
+		if err != nil {
+			return err
+		}
+		d.CenterCoord = center
+	}
+	boundary, err := parseBoundary(d.Polyline)
+	if err != nil {
+		return err
+	}
+	d.Boundary = boundary
+	return nil
+}
+
+// Walk 深度优先遍历行政区树，fn 返回 false 时停止遍历
+func (d *District) Walk(fn func(d *District) bool) bool {
+	if !fn(d) {
+		return false
+	}
+	for i := range d.Districts {
+		if !d.Districts[i].Walk(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseBoundary 解析 "经度,纬度;经度,纬度|经度,纬度;..." 格式的边界
+func parseBoundary(polyline string) ([][]Coordinate, error) {
+	if polyline == "" {
+		return nil, nil
+	}
+	parts := strings.Split(polyline, "|")
+	rings := make([][]Coordinate, 0, len(parts))
+	for _, part := range parts {
+		ring, err := ParsePolyline(part)
+		if err != nil {
+			return nil, err
+		}
+		if len(ring) > 0 {
+			rings = append(rings, ring)
+		}
+	}
+	return rings, nil
+}
+
+// District 行政区域查询 - 查询行政区及其下级行政区，可返回边界坐标
+// https://lbs.amap.com/api/webservice/guide/api/district
+func (c *Client) District(req *DistrictRequest) (*DistrictResponse, error) {
+	return c.DistrictContext(context.Background(), req)
+}
+
+// DistrictContext 同 District，支持通过 context 控制取消与超时
+func (c *Client) DistrictContext(ctx context.Context, req *DistrictRequest) (*DistrictResponse, error) {
+	params := url.Values{}
+	if req.Keywords != "" {
+		params.Set("keywords", req.Keywords)
+	}
+
+	params.Set("subdistrict", strconv.Itoa(req.Subdistrict))
+
+	setPaging(params, req.Offset, req.Page)
+
+	if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	if req.Filter != "" {
+		params.Set("filter", req.Filter)
+	}
+
+	// 使用带缓存的请求
+	body, err := c.doRequestWithCache(ctx, "config/district", params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DistrictResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,69 @@
+package amap
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const districtJSON = `{"status":"1","info":"OK","infocode":"10000","count":"1","suggestion":{"keywords":[],"cities":[]},"districts":[{"citycode":"010","adcode":"110000","name":"北京市","polyline":"116.1,39.9;116.2,39.9;116.2,40.0;116.1,39.9|117.1,40.1;117.2,40.1;117.1,40.1","center":"116.407387,39.904179","level":"province","districts":[{"citycode":"010","adcode":"110105","name":"朝阳区","center":"116.443205,39.921506","level":"district","districts":[{"citycode":"010","adcode":"110105","name":"望京街道","center":"116.47,39.99","level":"street","districts":[]}]},{"citycode":[],"adcode":"110101","name":"东城区","center":"116.416357,39.928353","level":"district","districts":[]}]}]}`
+
+func TestDistrictJSONParsing(t *testing.T) {
+	var resp DistrictResponse
+	if err := json.Unmarshal([]byte(districtJSON), &resp); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+
+	beijing := resp.Districts[0]
+	if beijing.CenterCoord != (Coordinate{Lng: 116.407387, Lat: 39.904179}) {
+		t.Errorf("中心点解析错误: %+v", beijing.CenterCoord)
+	}
+	if len(beijing.Boundary) != 2 || len(beijing.Boundary[0]) != 4 || len(beijing.Boundary[1]) != 3 {
+		t.Fatalf("边界解析错误: %+v", beijing.Boundary)
+	}
+	if beijing.Boundary[1][0] != (Coordinate{Lng: 117.1, Lat: 40.1}) {
+		t.Errorf("边界坐标错误: %+v", beijing.Boundary[1][0])
+	}
+	if beijing.Districts[1].CityCode != "" {
+		t.Errorf("空字段解析错误: %s", beijing.Districts[1].CityCode)
+	}
+
+	var levels []string
+	beijing.Walk(func(d *District) bool {
+		levels = append(levels, d.Level)
+		return true
+	})
+	want := []string{DistrictLevelProvince, DistrictLevelDistrict, DistrictLevelStreet, DistrictLevelDistrict}
+	if len(levels) != len(want) {
+		t.Fatalf("遍历结果错误: %v", levels)
+	}
+	for i := range want {
+		if levels[i] != want[i] {
+			t.Fatalf("遍历结果错误: %v", levels)
+		}
+	}
+}
+
+func TestDistrict(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/config/district" || q.Get("keywords") != "北京" || q.Get("subdistrict") != "2" ||
+			q.Get("extensions") != "all" || q.Get("filter") != "110000" {
+			t.Errorf("请求参数错误: %s", r.URL.String())
+		}
+		_, _ = w.Write([]byte(districtJSON))
+	})
+
+	resp, err := client.District(&DistrictRequest{
+		Keywords:    "北京",
+		Subdistrict: 2,
+		Extensions:  "all",
+		Filter:      "110000",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Districts) != 1 || len(resp.Districts[0].Districts) != 2 {
+		t.Fatalf("结果错误: %+v", resp.Districts)
+	}
+}
@@ -0,0 +1,157 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 驾车路径规划常用策略（v3 接口）
+const (
+	DrivingStrategySpeed        = 0  // 速度优先
+	DrivingStrategyCost         = 1  // 费用优先，不走收费路段
+	DrivingStrategyDistance     = 2  // 距离优先
+	DrivingStrategyNoExpressway = 3  // 不走快速路
+	DrivingStrategyAvoidJam     = 4  // 躲避拥堵
+	DrivingStrategyMultiple     = 10 // 返回多条结果，躲避拥堵、路程较短、尽量缩短时间
+	DrivingStrategyNoHighway    = 13 // 不走高速
+	DrivingStrategyAvoidCharge  = 14 // 避免收费
+)
+
+// 驾车 v5 接口可返回的扩展字段，通过 DrivingRequest.ShowFields 指定
+const (
+	ShowFieldCost     = "cost"     // 耗时、费用、红绿灯等
+	ShowFieldTmcs     = "tmcs"     // 路况信息
+	ShowFieldNavi     = "navi"     // 导航动作
+	ShowFieldCities   = "cities"   // 途经城市
+	ShowFieldPolyline = "polyline" // 路段坐标点串
+)
+
+// DrivingStrategy 返回驾车策略的指针，用于设置 DrivingRequest.Strategy 与 TruckRequest.Strategy
+func DrivingStrategy(v int) *int {
+	return &v
+}
+
+// maxWaypoints 驾车途经点数量上限
+const maxWaypoints = 16
+
+// DrivingRequest 驾车路径规划请求参数
+type DrivingRequest struct {
+	Origin        string         // 起点坐标，必填 "经度,纬度"
+	Destination   string         // 终点坐标，必填 "经度,纬度"
+	Waypoints     []string       // 途经点坐标，可选，最多 16 个
+	AvoidPolygons [][]Coordinate // 避让区域，可选，最多 32 个多边形
+	AvoidRoad     string         // 避让道路名，可选，只支持一条
+	Strategy      *int           // 驾车策略，可选，见 DrivingStrategy* 常量，nil 时使用接口默认策略
+	Province      string         // 车牌省份简称，可选，如 "京"
+	Number        string         // 车牌号码（不含省份），可选，用于判断限行
+	CarType       int            // 车辆类型：0 燃油车(默认)，1 纯电动，2 插电混动
+	NoFerry       bool           // 是否不使用轮渡
+	Extensions    string         // 返回结果控制：base(默认) 或 all，仅 v3 接口有效
+	ShowFields    []string       // 扩展字段，设置后使用 v5 接口，见 ShowField* 常量
+}
+
+// DrivingResponse 驾车路径规划响应
+type DrivingResponse struct {
+	BaseResponse
+	Count string `json:"count"` // 路径规划方案总数
+	Route Route  `json:"route"` // 路径规划方案
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况，以及货车接口 v4 版本的 data 响应格式
+func (r *DrivingResponse) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		BaseResponse
+		errcodeEnvelope
+		Count numString       `json:"count"`
+		Route Route           `json:"route"`
+		Data  json.RawMessage `json:"data"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	if aux.Status == "" && aux.ErrCode != nil {
+		aux.BaseResponse = aux.errcodeEnvelope.base()
+		if err := unmarshalLenient(aux.Data, &aux); err != nil {
+			return err
+		}
+	}
+	*r = DrivingResponse{BaseResponse: aux.BaseResponse, Count: string(aux.Count), Route: aux.Route}
+	return nil
+}
+
+// Driving 驾车路径规划 - 规划起点到终点的驾车路线，可指定途经点与避让区域
+// 设置 ShowFields 时使用 v5 接口，否则使用 v3 接口
+// https://lbs.amap.com/api/webservice/guide/api/direction
+func (c *Client) Driving(req *DrivingRequest) (*DrivingResponse, error) {
+	return c.DrivingContext(context.Background(), req)
+}
+
+// DrivingContext 同 Driving，支持通过 context 控制取消与超时
+func (c *Client) DrivingContext(ctx context.Context, req *DrivingRequest) (*DrivingResponse, error) {
+	if err := checkOriginDestination(req.Origin, req.Destination); err != nil {
+		return nil, err
+	}
+	if len(req.Waypoints) > maxWaypoints {
+		return nil, fmt.Errorf("%w: waypoints 最多 %d 个，实际 %d 个", ErrInvalidParams, maxWaypoints, len(req.Waypoints))
+	}
+
+	v5 := len(req.ShowFields) > 0
+
+	params := url.Values{}
+	params.Set("origin", req.Origin)
+	params.Set("destination", req.Destination)
+	setAvoidance(params, req.Waypoints, req.AvoidPolygons)
+
+	if req.AvoidRoad != "" {
+		params.Set("avoidroad", req.AvoidRoad)
+	}
+
+	if req.Strategy != nil {
+		params.Set("strategy", strconv.Itoa(*req.Strategy))
+	}
+
+	if req.Number != "" {
+		if v5 {
+			params.Set("plate", req.Province+req.Number)
+		} else {
+			params.Set("province", req.Province)
+			params.Set("number", req.Number)
+		}
+	}
+
+	if req.CarType > 0 {
+		params.Set("cartype", strconv.Itoa(req.CarType))
+	}
+
+	if req.NoFerry {
+		params.Set("ferry", "1")
+	}
+
+	endpoint := "direction/driving"
+	if v5 {
+		endpoint = "v5/direction/driving"
+		params.Set("show_fields", strings.Join(req.ShowFields, ","))
+	} else if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	body, err := c.doRequestWithCache(ctx, endpoint, params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DrivingResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,109 @@
+package amap
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const (
+	drivingJSON   = `{"status":"1","info":"OK","infocode":"10000","count":"1","route":{"origin":"116.481028,39.989643","destination":"116.434446,39.90816","taxi_cost":"38","paths":[{"distance":"12420","duration":"1800","strategy":"速度最快","tolls":"0","toll_distance":"0","restriction":"0","traffic_lights":"12","steps":[{"instruction":"向西南行驶179米右转","orientation":"西南","road":"阜通东大街","distance":"179","tolls":"0","toll_distance":"0","toll_road":[],"duration":"40","polyline":"116.481028,39.989643;116.480286,39.988992","action":"右转","assistant_action":[]},{"instruction":"向西行驶1.2千米","orientation":"西","road":[],"distance":"1200","tolls":"0","toll_distance":"0","toll_road":[],"duration":"300","polyline":"116.480286,39.988992;116.468234,39.987661","action":[],"assistant_action":"到达目的地"}]}]}}`
+	drivingV5JSON = `{"status":"1","info":"OK","infocode":"10000","count":"1","route":{"origin":"116.481028,39.989643","destination":"116.434446,39.90816","taxi_cost":"40","paths":[{"distance":"12500","restriction":"0","cost":{"duration":"1900","tolls":"5","toll_distance":"800","traffic_lights":"10"},"steps":[{"instruction":"向西南行驶179米右转","orientation":"西南","road_name":"阜通东大街","step_distance":"179","cost":{"duration":"40","tolls":"0","toll_distance":"0","toll_road":""},"navi":{"action":"右转","assistant_action":""},"polyline":"116.481028,39.989643;116.480286,39.988992"}]}]}}`
+)
+
+func TestDrivingJSONParsing(t *testing.T) {
+	var resp DrivingResponse
+	if err := json.Unmarshal([]byte(drivingJSON), &resp); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+	if resp.Route.TaxiCost != 38 || len(resp.Route.Paths) != 1 {
+		t.Fatalf("路线解析错误: %+v", resp.Route)
+	}
+	path := resp.Route.Paths[0]
+	if path.Distance != 12420 || path.Duration != 1800 || path.TrafficLights != 12 || len(path.Steps) != 2 {
+		t.Errorf("方案解析错误: %+v", path)
+	}
+	step := path.Steps[0]
+	if step.Road != "阜通东大街" || step.Distance != 179 || step.Duration != 40 || step.Action != "右转" || len(step.Polyline) != 2 {
+		t.Errorf("路段解析错误: %+v", step)
+	}
+	if polyline := path.Polyline(); len(polyline) != 3 {
+		t.Errorf("完整坐标串应去除重复点，实际 %d 个", len(polyline))
+	}
+
+	var v5 DrivingResponse
+	if err := json.Unmarshal([]byte(drivingV5JSON), &v5); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+	path = v5.Route.Paths[0]
+	if path.Duration != 1900 || path.Tolls != 5 || path.TollDistance != 800 || path.TrafficLights != 10 {
+		t.Errorf("v5 方案解析错误: %+v", path)
+	}
+	step = path.Steps[0]
+	if step.Road != "阜通东大街" || step.Distance != 179 || step.Duration != 40 || step.Action != "右转" {
+		t.Errorf("v5 路段解析错误: %+v", step)
+	}
+}
+
+func TestDrivingRequest(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/v3/direction/driving":
+			if q.Get("waypoints") != "116.1,39.1;116.2,39.2" || q.Get("province") != "京" || q.Get("number") != "A12345" || q.Get("ferry") != "1" {
+				t.Errorf("v3 请求参数错误: %s", r.URL.RawQuery)
+			}
+			if q.Get("avoidpolygons") != "116.1,39.1;116.2,39.2|116.3,39.3;116.4,39.4" {
+				t.Errorf("避让区域参数错误: %s", q.Get("avoidpolygons"))
+			}
+			if q.Has("strategy") {
+				t.Errorf("未设置策略时不应传递 strategy: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(drivingJSON))
+		case "/v5/direction/driving":
+			if q.Get("plate") != "京A12345" || q.Get("show_fields") != "cost,polyline" {
+				t.Errorf("v5 请求参数错误: %s", r.URL.RawQuery)
+			}
+			if q.Get("strategy") != "0" {
+				t.Errorf("策略 0 应正常传递: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(drivingV5JSON))
+		default:
+			t.Errorf("未知接口: %s", r.URL.Path)
+		}
+	})
+
+	req := &DrivingRequest{
+		Origin:      "116.481028,39.989643",
+		Destination: "116.434446,39.90816",
+		Waypoints:   []string{"116.1,39.1", "116.2,39.2"},
+		AvoidPolygons: [][]Coordinate{
+			{{Lng: 116.1, Lat: 39.1}, {Lng: 116.2, Lat: 39.2}},
+			{{Lng: 116.3, Lat: 39.3}, {Lng: 116.4, Lat: 39.4}},
+		},
+		Province: "京",
+		Number:   "A12345",
+		NoFerry:  true,
+	}
+	resp, err := client.Driving(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Route.Paths[0].Distance != 12420 {
+		t.Errorf("v3 响应解析错误: %+v", resp.Route)
+	}
+
+	req.ShowFields = []string{ShowFieldCost, ShowFieldPolyline}
+	req.Strategy = DrivingStrategy(DrivingStrategySpeed)
+	resp, err = client.Driving(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Route.Paths[0].Duration != 1900 {
+		t.Errorf("v5 响应解析错误: %+v", resp.Route)
+	}
+
+	if _, err := client.Driving(&DrivingRequest{Origin: "116.481028,39.989643"}); !IsInvalidParams(err) {
+		t.Errorf("缺少终点应返回参数错误，实际: %v", err)
+	}
+}
@@ -0,0 +1,244 @@
+package amap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// 错误分类，可配合 errors.Is 使用
+var (
+	// ErrInvalidKey Key 无效、被封禁、签名错误或无权限
+	ErrInvalidKey = errors.New("amap: invalid key")
+	// ErrQuotaExceeded 日调用量或套餐配额用尽
+	ErrQuotaExceeded = errors.New("amap: quota exceeded")
+	// ErrQPSExceeded 访问过于频繁，QPS 超限
+	ErrQPSExceeded = errors.New("amap: qps exceeded")
+	// ErrInvalidParams 请求参数错误
+	ErrInvalidParams = errors.New("amap: invalid params")
+	// ErrServiceUnavailable 服务不可用或服务端异常
+	ErrServiceUnavailable = errors.New("amap: service unavailable")
+)
+
+// maxErrorBodySize HTTPError 中保留的响应体最大字节数
+const maxErrorBodySize = 512
+
+// infoCode infocode 的说明与分类
+type infoCode struct {
+	name      string // 高德返回的 info
+	kind      error  // 错误分类
+	retryable bool   // 是否为可重试的瞬时错误
+}
+
+// infoCodes 高德 infocode 码表
+// https://lbs.amap.com/api/webservice/guide/tools/info
+var infoCodes = map[string]infoCode{
+	"10001": {"INVALID_USER_KEY", ErrInvalidKey, false},
+	"10002": {"SERVICE_NOT_AVAILABLE", ErrServiceUnavailable, false},
+	"10003": {"DAILY_QUERY_OVER_LIMIT", ErrQuotaExceeded, false},
+	"10004": {"ACCESS_TOO_FREQUENT", ErrQPSExceeded, true},
+	"10005": {"INVALID_USER_IP", ErrInvalidKey, false},
+	"10006": {"INVALID_USER_DOMAIN", ErrInvalidKey, false},
+	"10007": {"INVALID_USER_SIGNATURE", ErrInvalidKey, false},
+	"10008": {"INVALID_USER_SCODE", ErrInvalidKey, false},
+	"10009": {"USERKEY_PLAT_NOMATCH", ErrInvalidKey, false},
+	"10010": {"IP_QUERY_OVER_LIMIT", ErrQuotaExceeded, false},
+	"10011": {"NOT_SUPPORT_HTTPS", ErrServiceUnavailable, false},
+	"10012": {"INSUFFICIENT_PRIVILEGES", ErrInvalidKey, false},
+	"10013": {"USER_KEY_RECYCLED", ErrInvalidKey, false},
+	"10014": {"QPS_HAS_EXCEEDED_THE_LIMIT", ErrQPSExceeded, true},
+	"10015": {"GATEWAY_TIMEOUT", ErrServiceUnavailable, true},
+	"10016": {"SERVER_IS_BUSY", ErrServiceUnavailable, true},
+	"10017": {"RESOURCE_UNAVAILABLE", ErrServiceUnavailable, false},
+	"10019": {"CQPS_HAS_EXCEEDED_THE_LIMIT", ErrQPSExceeded, true},
+	"10020": {"CKQPS_HAS_EXCEEDED_THE_LIMIT", ErrQPSExceeded, true},
+	"10021": {"CUQPS_HAS_EXCEEDED_THE_LIMIT", ErrQPSExceeded, true},
+	"10026": {"INVALID_REQUEST", ErrInvalidKey, false},
+	"10029": {"ABROAD_DAILY_QUERY_OVER_LIMIT", ErrQuotaExceeded, false},
+	"10041": {"NO_EFFECTIVE_INTERFACE", ErrInvalidKey, false},
+	"10044": {"USER_DAILY_QUERY_OVER_LIMIT", ErrQuotaExceeded, false},
+	"10045": {"USER_ABROAD_DAILY_QUERY_OVER_LIMIT", ErrQuotaExceeded, false},
+	"20000": {"INVALID_PARAMS", ErrInvalidParams, false},
+	"20001": {"MISSING_REQUIRED_PARAMS", ErrInvalidParams, false},
+	"20002": {"ILLEGAL_REQUEST", ErrInvalidParams, false},
+	"20003": {"UNKNOWN_ERROR", ErrServiceUnavailable, false},
+	"20011": {"INSUFFICIENT_ABROAD_PRIVILEGES", ErrInvalidKey, false},
+	"20012": {"ILLEGAL_CONTENT", ErrInvalidParams, false},
+	"20800": {"OUT_OF_SERVICE", ErrInvalidParams, false},
+	"20801": {"NO_ROADS_NEARBY", ErrInvalidParams, false},
+	"20802": {"ROUTE_FAIL", ErrInvalidParams, false},
+	"20803": {"OVER_DIRECTION_RANGE", ErrInvalidParams, false},
+	"40000": {"QUOTA_PLAN_RUN_OUT", ErrQuotaExceeded, false},
+	"40001": {"GEOFENCE_MAX_COUNT_REACHED", ErrQuotaExceeded, false},
+	"40002": {"SERVICE_EXPIRED", ErrServiceUnavailable, false},
+	"40003": {"ABROAD_QUOTA_PLAN_RUN_OUT", ErrQuotaExceeded, false},
+}
+
+// lookupInfoCode 查询 infocode，3 开头的引擎错误统一视为可重试的服务异常
+func lookupInfoCode(code string) (infoCode, bool) {
+	if c, ok := infoCodes[code]; ok {
+		return c, true
+	}
+	if len(code) == 5 && code[0] == '3' {
+		return infoCode{name: "ENGINE_RESPONSE_DATA_ERROR", kind: ErrServiceUnavailable, retryable: true}, true
+	}
+	return infoCode{}, false
+}
+
+// APIError 高德返回的业务错误（status 不为 1）
+type APIError struct {
+	Status   string     // 返回状态
+	Info     string     // 返回的状态信息
+	InfoCode string     // 返回状态说明码
+	Endpoint string     // 请求的接口，如 "geocode/geo"
+	Params   url.Values // 请求参数，已移除 key 与 sig
+}
+
+// newAPIError 根据响应创建 APIError
+func newAPIError(r *BaseResponse, endpoint string, params url.Values) *APIError {
+	return &APIError{
+		Status:   r.Status,
+		Info:     r.Info,
+		InfoCode: r.InfoCode,
+		Endpoint: endpoint,
+		Params:   redactParams(params),
+	}
+}
+
+// Error 实现 error 接口
+func (e *APIError) Error() string {
+	if e.Endpoint == "" {
+		return fmt.Sprintf("API错误: %s (代码: %s)", e.Info, e.InfoCode)
+	}
+	return fmt.Sprintf("API错误: %s (代码: %s, 接口: %s)", e.Info, e.InfoCode, e.Endpoint)
+}
+
+// Is 支持 errors.Is 按错误分类匹配，如 errors.Is(err, ErrQuotaExceeded)
+func (e *APIError) Is(target error) bool {
+	c, ok := lookupInfoCode(e.InfoCode)
+	return ok && c.kind == target
+}
+
+// Retryable 是否为可重试的瞬时错误
+func (e *APIError) Retryable() bool {
+	c, ok := lookupInfoCode(e.InfoCode)
+	return ok && c.retryable
+}
+
+// HTTPError 非 200 的 HTTP 响应
+type HTTPError struct {
+	StatusCode int    // HTTP 状态码
+	Body       string // 响应体，超过 512 字节时截断
+	Endpoint   string // 请求的接口
+}
+
+// newHTTPError 创建 HTTPError，截断过长的响应体
+func newHTTPError(statusCode int, body []byte, endpoint string) *HTTPError {
+	return &HTTPError{
+		StatusCode: statusCode,
+		Body:       truncateBody(body, maxErrorBodySize),
+		Endpoint:   endpoint,
+	}
+}
+
+// Error 实现 error 接口
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP状态码错误: %d, 响应: %s", e.StatusCode, e.Body)
+}
+
+// Is 支持 errors.Is 匹配，429 视为 QPS 超限，5xx 视为服务不可用
+func (e *HTTPError) Is(target error) bool {
+	switch {
+	case e.StatusCode == http.StatusTooManyRequests:
+		return target == ErrQPSExceeded
+	case e.StatusCode >= http.StatusInternalServerError:
+		return target == ErrServiceUnavailable
+	}
+	return false
+}
+
+// Retryable 是否为可重试的瞬时错误
+func (e *HTTPError) Retryable() bool {
+	return e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError
+}
+
+// IsInvalidKey Key 无效、被封禁、签名错误或无权限
+func IsInvalidKey(err error) bool {
+	return errors.Is(err, ErrInvalidKey)
+}
+
+// IsQuotaExceeded 日调用量或套餐配额用尽，包含客户端配额计数
+func IsQuotaExceeded(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrDailyQuotaExceeded)
+}
+
+// IsQPSExceeded QPS 超限，包含客户端限流
+func IsQPSExceeded(err error) bool {
+	return errors.Is(err, ErrQPSExceeded) || errors.Is(err, ErrRateLimited)
+}
+
+// IsInvalidParams 请求参数错误
+func IsInvalidParams(err error) bool {
+	return errors.Is(err, ErrInvalidParams)
+}
+
+// IsServiceUnavailable 服务不可用或服务端异常
+func IsServiceUnavailable(err error) bool {
+	return errors.Is(err, ErrServiceUnavailable)
+}
+
+// IsRetryable 是否为可重试的瞬时错误：QPS 超限、服务端繁忙、5xx 以及网络错误
+// 网络错误包含 HTTP 客户端超时与单次尝试超时，与 DefaultRetryClassifier 一致；
+// 调用方 context 取消或超时导致的失败不可重试
+func IsRetryable(err error) bool {
+	var ctxErr *contextError
+	if errors.As(err, &ctxErr) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var r interface{ Retryable() bool }
+	if errors.As(err, &r) {
+		return r.Retryable()
+	}
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	// 未发出请求直接返回的超时来自调用方的 context
+	var urlErr *url.Error
+	if errors.Is(err, context.DeadlineExceeded) && !errors.As(err, &urlErr) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// contextError 调用方 context 取消或超时时请求的错误，不可重试
+type contextError struct {
+	err error
+}
+
+func (e *contextError) Error() string { return e.err.Error() }
+func (e *contextError) Unwrap() error { return e.err }
+
+// truncateBody 截断响应体，并去除截断产生的不完整字符
+func truncateBody(body []byte, n int) string {
+	if len(body) > n {
+		body = body[:n]
+	}
+	return strings.ToValidUTF8(string(body), "")
+}
+
+// redactParams 复制请求参数并移除 key 与 sig
+func redactParams(params url.Values) url.Values {
+	out := make(url.Values, len(params))
+	for k, v := range params {
+		if k == "key" || k == "sig" {
+			continue
+		}
+		out[k] = append([]string(nil), v...)
+	}
+	return out
+}
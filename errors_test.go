@@ -0,0 +1,147 @@
+package amap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAPIErrorClassification(t *testing.T) {
+	tests := []struct {
+		code      string
+		check     func(error) bool
+		retryable bool
+	}{
+		{"10001", IsInvalidKey, false},
+		{"10007", IsInvalidKey, false},
+		{"10003", IsQuotaExceeded, false},
+		{"10044", IsQuotaExceeded, false},
+		{"10019", IsQPSExceeded, true},
+		{"10021", IsQPSExceeded, true},
+		{"20000", IsInvalidParams, false},
+		{"20801", IsInvalidParams, false},
+		{"10016", IsServiceUnavailable, true},
+		{"30001", IsServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		var err error = &APIError{Status: "0", InfoCode: tt.code}
+		if !tt.check(err) {
+			t.Errorf("infocode %s 分类错误", tt.code)
+		}
+		if IsRetryable(err) != tt.retryable {
+			t.Errorf("infocode %s 期望 retryable=%v", tt.code, tt.retryable)
+		}
+	}
+}
+
+func TestAPIErrorFromResponse(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","info":"INVALID_USER_KEY","infocode":"10001"}`))
+	})
+	client.SetSecret("secret")
+
+	_, err := client.Geocode(&GeocodeRequest{Address: "北京市朝阳区阜通东大街6号"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("期望 *APIError，实际为: %T", err)
+	}
+	if apiErr.Endpoint != "geocode/geo" || apiErr.InfoCode != "10001" {
+		t.Fatalf("错误信息不完整: %+v", apiErr)
+	}
+	if apiErr.Params.Get("address") == "" {
+		t.Error("请求参数缺失")
+	}
+	if apiErr.Params.Has("key") || apiErr.Params.Has("sig") {
+		t.Error("请求参数未移除 key 与 sig")
+	}
+	if !errors.Is(err, ErrInvalidKey) {
+		t.Error("期望 errors.Is(err, ErrInvalidKey)")
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(strings.Repeat("服务", 1000)))
+	})
+
+	_, err := client.IP(&IPRequest{IP: "114.247.50.2"})
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		t.Fatalf("期望 *HTTPError，实际为: %T", err)
+	}
+	if httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("状态码错误: %d", httpErr.StatusCode)
+	}
+	if len(httpErr.Body) > maxErrorBodySize {
+		t.Errorf("响应体未截断: %d", len(httpErr.Body))
+	}
+	if !IsServiceUnavailable(err) || !IsRetryable(err) {
+		t.Error("5xx 应视为可重试的服务不可用")
+	}
+}
+
+func TestIsRetryableContextErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"context canceled", context.Canceled, false},
+		{"context deadline", context.DeadlineExceeded, false},
+		{"url canceled", &url.Error{Op: "Get", URL: "http://x", Err: context.Canceled}, false},
+		{"url deadline", fmt.Errorf("http get err: %w", &url.Error{Op: "Get", URL: "http://x", Err: context.DeadlineExceeded}), true},
+		{"caller deadline", &contextError{err: &url.Error{Op: "Get", URL: "http://x", Err: context.DeadlineExceeded}}, false},
+		{"connection refused", &url.Error{Op: "Get", URL: "http://x", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+	}
+	for _, tt := range tests {
+		if got := IsRetryable(tt.err); got != tt.want {
+			t.Errorf("%s: IsRetryable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// 调用方取消的请求不可重试
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.IPContext(ctx, &IPRequest{IP: "114.247.50.2"}); err == nil || IsRetryable(err) {
+		t.Errorf("已取消的请求不应可重试: %v", err)
+	}
+}
+
+func TestIsRetryableTimeout(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	client.SetTimeout(50 * time.Millisecond)
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2})
+
+	// HTTP 客户端超时会被重试，IsRetryable 应与重试策略一致
+	_, err := client.IP(&IPRequest{IP: "114.247.50.2"})
+	if n := calls.Load(); n != 2 || !IsRetryable(err) {
+		t.Errorf("HTTP 客户端超时应可重试: calls=%d err=%v", n, err)
+	}
+
+	// 调用方 context 超时不可重试
+	client.SetTimeout(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = client.IPContext(ctx, &IPRequest{IP: "114.247.50.2"})
+	if !errors.Is(err, context.DeadlineExceeded) || IsRetryable(err) {
+		t.Errorf("调用方 context 超时不应可重试: %v", err)
+	}
+}
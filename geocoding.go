@@ -1,6 +1,7 @@
 package amap
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 	"strconv"
@@ -36,29 +37,34 @@ type Geocode struct {
 
 // GetLongitude 获取经度
 func (g *Geocode) GetLongitude() float64 {
-	coords := strings.Split(g.Location, ",")
-	if len(coords) >= 2 {
-		if lng, err := strconv.ParseFloat(coords[0], 64); err == nil {
-			return lng
-		}
-	}
-	return 0
+	lng, _ := parseLocation(g.Location)
+	return lng
 }
 
 // GetLatitude 获取纬度
 func (g *Geocode) GetLatitude() float64 {
-	coords := strings.Split(g.Location, ",")
+	_, lat := parseLocation(g.Location)
+	return lat
+}
+
+// parseLocation 解析 "经度,纬度" 格式的坐标，解析失败时返回 0
+func parseLocation(location string) (lng, lat float64) {
+	coords := strings.Split(location, ",")
 	if len(coords) >= 2 {
-		if lat, err := strconv.ParseFloat(coords[1], 64); err == nil {
-			return lat
-		}
+		lng, _ = strconv.ParseFloat(coords[0], 64)
+		lat, _ = strconv.ParseFloat(coords[1], 64)
 	}
-	return 0
+	return lng, lat
 }
 
 // Geocode 地理编码 - 将地址转换为经纬度坐标
 // https://lbs.amap.com/api/webservice/guide/api/georegeo
 func (c *Client) Geocode(req *GeocodeRequest) (*GeocodeResponse, error) {
+	return c.GeocodeContext(context.Background(), req)
+}
+
+// GeocodeContext 同 Geocode，支持通过 context 控制取消与超时
+func (c *Client) GeocodeContext(ctx context.Context, req *GeocodeRequest) (*GeocodeResponse, error) {
 	params := url.Values{}
 	params.Set("address", req.Address)
 
@@ -67,7 +73,7 @@ func (c *Client) Geocode(req *GeocodeRequest) (*GeocodeResponse, error) {
 	}
 
 	// 使用带缓存的请求
-	body, err := c.doRequestWithCache("geocode/geo", params, req)
+	body, err := c.doRequestWithCache(ctx, "geocode/geo", params, req)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +117,12 @@ type Regeocode struct {
 	AOIs             []AOI            `json:"aois,omitempty"`          // AOI信息列表
 }
 
+// UnmarshalJSON 兼容海域及境外坐标 formatted_address 返回 [] 的情况
+func (r *Regeocode) UnmarshalJSON(data []byte) error {
+	type alias Regeocode
+	return unmarshalLenient(data, (*alias)(r))
+}
+
 // AddressComponent 地址元素
 type AddressComponent struct {
 	Country       string           `json:"country"`       // 国家
@@ -128,6 +140,12 @@ type AddressComponent struct {
 	BusinessAreas [][]BusinessArea `json:"businessAreas"` // 商圈列表（二维数组）
 }
 
+// UnmarshalJSON 兼容直辖市 city 及海域坐标 district、adcode 等字段返回 [] 的情况
+func (a *AddressComponent) UnmarshalJSON(data []byte) error {
+	type alias AddressComponent
+	return unmarshalLenient(data, (*alias)(a))
+}
+
 // Neighborhood 社区信息
 type Neighborhood struct {
 	Name []string `json:"name"` // 社区名称列表
@@ -225,16 +243,122 @@ func (s *StreetNumber) GetDistance() string {
 }
 
 // POI POI信息
+// 逆地理编码与搜索POI接口共用，搜索接口会返回更多字段
 type POI struct {
-	ID           string `json:"id"`           // POI ID
-	Name         string `json:"name"`         // POI名称
-	Type         string `json:"type"`         // POI类型
-	Tel          string `json:"tel"`          // 电话
-	Distance     string `json:"distance"`     // 距离
-	Direction    string `json:"direction"`    // 方向
-	Address      string `json:"address"`      // 地址
-	Location     string `json:"location"`     // 坐标点
-	BusinessArea string `json:"businessarea"` // 商圈名称
+	ID             string      `json:"id"`            // POI ID
+	Parent         string      `json:"parent"`        // 父POI ID
+	ChildType      string      `json:"childtype"`     // 子POI类型
+	Name           string      `json:"name"`          // POI名称
+	Type           string      `json:"type"`          // POI类型
+	TypeCode       string      `json:"typecode"`      // POI类型编码
+	BizType        string      `json:"biz_type"`      // 行业类型
+	Tel            string      `json:"tel"`           // 电话
+	Distance       string      `json:"distance"`      // 距离（原始字符串），保留用于兼容
+	DistanceMeters float64     `json:"-"`             // 距离，单位：米，无距离信息时为 0
+	Direction      string      `json:"direction"`     // 方向
+	Address        string      `json:"address"`       // 地址
+	Location       string      `json:"location"`      // 坐标点
+	BusinessArea   string      `json:"businessarea"`  // 商圈名称
+	PName          string      `json:"pname"`         // 省份名称
+	PCode          string      `json:"pcode"`         // 省份编码
+	CityName       string      `json:"cityname"`      // 城市名称
+	CityCode       string      `json:"citycode"`      // 城市编码
+	AdName         string      `json:"adname"`        // 区县名称
+	AdCode         string      `json:"adcode"`        // 区域编码
+	PostCode       string      `json:"postcode"`      // 邮编
+	Website        string      `json:"website"`       // 网址
+	Email          string      `json:"email"`         // 电子邮箱
+	EntrLocation   string      `json:"entr_location"` // 入口经纬度
+	ExitLocation   string      `json:"exit_location"` // 出口经纬度
+	NaviPOIID      string      `json:"navi_poiid"`    // 地图编号
+	GridCode       string      `json:"gridcode"`      // 地理格ID
+	Alias          string      `json:"alias"`         // 别名
+	Tag            string      `json:"tag"`           // 特色内容
+	IndoorMap      string      `json:"indoor_map"`    // 是否有室内地图：0 无，1 有
+	IndoorData     *IndoorData `json:"indoor_data"`   // 室内地图信息
+	BizExt         *BizExt     `json:"biz_ext"`       // 深度信息，extensions=all 时返回
+	Photos         []Photo     `json:"photos"`        // 照片，extensions=all 时返回
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+// 搜索接口的商圈名称字段为 business_area，统一解析到 BusinessArea
+func (p *POI) UnmarshalJSON(data []byte) error {
+	type alias POI
+	var aux struct {
+		alias
+		BizArea string `json:"business_area"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	if aux.BusinessArea == "" {
+		aux.BusinessArea = aux.BizArea
+	}
+	aux.DistanceMeters = parseFloat(aux.Distance)
+	*p = POI(aux.alias)
+	return nil
+}
+
+// GetLongitude 获取经度
+func (p *POI) GetLongitude() float64 {
+	lng, _ := parseLocation(p.Location)
+	return lng
+}
+
+// GetLatitude 获取纬度
+func (p *POI) GetLatitude() float64 {
+	_, lat := parseLocation(p.Location)
+	return lat
+}
+
+// GetDistance 获取距离（米），无距离信息时返回 0，同 DistanceMeters
+func (p *POI) GetDistance() float64 {
+	if p.DistanceMeters != 0 {
+		return p.DistanceMeters
+	}
+	return parseFloat(p.Distance)
+}
+
+// IndoorData 室内地图信息
+type IndoorData struct {
+	CPID      string `json:"cpid"`      // 所在建筑物POI ID
+	Floor     string `json:"floor"`     // 楼层索引
+	TrueFloor string `json:"truefloor"` // 楼层名称
+	CMSID     string `json:"cmsid"`     // 室内地图ID
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+func (d *IndoorData) UnmarshalJSON(data []byte) error {
+	type alias IndoorData
+	return unmarshalLenient(data, (*alias)(d))
+}
+
+// BizExt POI深度信息
+type BizExt struct {
+	Rating         string `json:"rating"`          // 评分
+	Cost           string `json:"cost"`            // 人均消费
+	MealOrdering   string `json:"meal_ordering"`   // 是否可订餐
+	SeatOrdering   string `json:"seat_ordering"`   // 是否可订座
+	TicketOrdering string `json:"ticket_ordering"` // 是否可订票
+	HotelOrdering  string `json:"hotel_ordering"`  // 是否可订房
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+func (b *BizExt) UnmarshalJSON(data []byte) error {
+	type alias BizExt
+	return unmarshalLenient(data, (*alias)(b))
+}
+
+// Photo POI照片
+type Photo struct {
+	Title string `json:"title"` // 图片介绍
+	URL   string `json:"url"`   // 图片地址
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+func (p *Photo) UnmarshalJSON(data []byte) error {
+	type alias Photo
+	return unmarshalLenient(data, (*alias)(p))
 }
 
 // Road 道路信息
@@ -278,6 +402,11 @@ type AOI struct {
 // Regeo 逆地理编码 - 将经纬度坐标转换为地址
 // https://lbs.amap.com/api/webservice/guide/api/georegeo
 func (c *Client) Regeo(req *RegeoRequest) (*RegeoResponse, error) {
+	return c.RegeoContext(context.Background(), req)
+}
+
+// RegeoContext 同 Regeo，支持通过 context 控制取消与超时
+func (c *Client) RegeoContext(ctx context.Context, req *RegeoRequest) (*RegeoResponse, error) {
 	params := url.Values{}
 	params.Set("location", req.Location)
 
@@ -302,7 +431,7 @@ func (c *Client) Regeo(req *RegeoRequest) (*RegeoResponse, error) {
 	}
 
 	// 使用带缓存的请求
-	body, err := c.doRequestWithCache("geocode/regeo", params, req)
+	body, err := c.doRequestWithCache(ctx, "geocode/regeo", params, req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,131 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// 输入提示返回的数据类型
+const (
+	DataTypeAll     = "all"     // 所有数据类型
+	DataTypePOI     = "poi"     // POI数据
+	DataTypeBus     = "bus"     // 公交站点数据
+	DataTypeBusLine = "busline" // 公交线路数据
+)
+
+// InputTipsRequest 输入提示请求参数
+type InputTipsRequest struct {
+	Keywords  string   // 查询关键字，必填
+	Type      []string // POI类型，可选
+	Location  string   // 坐标 "经度,纬度"，可选，优先返回此位置附近的结果
+	City      string   // 搜索城市，可选
+	CityLimit bool     // 是否仅返回指定城市的数据
+	DataType  string   // 返回的数据类型：DataTypeAll(默认)、DataTypePOI、DataTypeBus、DataTypeBusLine
+}
+
+// InputTipsResponse 输入提示响应
+type InputTipsResponse struct {
+	BaseResponse
+	Count string `json:"count"` // 返回结果总数
+	Tips  []Tip  `json:"tips"`  // 建议提示列表
+}
+
+// Tip 建议提示
+type Tip struct {
+	ID       string `json:"id"`       // ID，公交线路等非POI数据可能为空
+	Name     string `json:"name"`     // 名称
+	District string `json:"district"` // 所属区域
+	AdCode   string `json:"adcode"`   // 区域编码
+	Location string `json:"location"` // 坐标点，公交线路等数据可能为空
+	Address  string `json:"address"`  // 详细地址
+	TypeCode string `json:"typecode"` // POI类型编码
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+func (t *Tip) UnmarshalJSON(data []byte) error {
+	type alias Tip
+	return unmarshalLenient(data, (*alias)(t))
+}
+
+// GetLongitude 获取经度
+func (t *Tip) GetLongitude() float64 {
+	lng, _ := parseLocation(t.Location)
+	return lng
+}
+
+// GetLatitude 获取纬度
+func (t *Tip) GetLatitude() float64 {
+	_, lat := parseLocation(t.Location)
+	return lat
+}
+
+// normalizeKeywords 规范化关键字：去除首尾空白、合并连续空白并转为小写
+// 仅用于生成缓存键，使同一输入前缀的不同写法共用缓存
+func normalizeKeywords(keywords string) string {
+	return strings.ToLower(strings.Join(strings.Fields(keywords), " "))
+}
+
+// InputTips 输入提示 - 根据输入的关键字返回建议列表，适用于搜索框自动补全
+// 关键字去除首尾空白后原样请求，缓存键使用规范化后的关键字，设置 TipsCache 可使用独立的短期缓存
+// https://lbs.amap.com/api/webservice/guide/api/inputtips
+func (c *Client) InputTips(req *InputTipsRequest) (*InputTipsResponse, error) {
+	return c.InputTipsContext(context.Background(), req)
+}
+
+// InputTipsContext 同 InputTips，支持通过 context 控制取消与超时
+func (c *Client) InputTipsContext(ctx context.Context, req *InputTipsRequest) (*InputTipsResponse, error) {
+	keywords := strings.TrimSpace(req.Keywords)
+	if keywords == "" {
+		return nil, fmt.Errorf("%w: keywords 不能为空", ErrInvalidParams)
+	}
+
+	params := url.Values{}
+	params.Set("keywords", keywords)
+
+	if len(req.Type) > 0 {
+		params.Set("type", strings.Join(req.Type, "|"))
+	}
+
+	if req.Location != "" {
+		params.Set("location", req.Location)
+	}
+
+	if req.City != "" {
+		params.Set("city", req.City)
+	}
+
+	if req.CityLimit {
+		params.Set("citylimit", "true")
+	}
+
+	if req.DataType != "" {
+		params.Set("datatype", req.DataType)
+	}
+
+	cache := c.TipsCache
+	if cache == nil {
+		cache = c.Cache
+	}
+
+	// 使用带缓存的请求，缓存键使用规范化后的关键字
+	normalized := *req
+	normalized.Keywords = normalizeKeywords(keywords)
+	body, err := c.doCall(ctx, cache, "assistant/inputtips", params, &normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp InputTipsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
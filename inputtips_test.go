@@ -0,0 +1,53 @@
+package amap
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInputTips(t *testing.T) {
+	var calls int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/assistant/inputtips" || q.Get("keywords") != "KFC 望京" || q.Get("datatype") != DataTypePOI {
+			t.Errorf("请求参数错误: %s", r.URL.String())
+		}
+		_, _ = w.Write([]byte(`{"status":"1","count":"2","info":"OK","infocode":"10000","tips":[{"id":"B000A7BM4H","name":"肯德基(望京店)","district":"北京市朝阳区","adcode":"110105","location":"116.470098,39.992838","address":"望京街","typecode":"050301"},{"id":[],"name":"KFC","district":"北京市","adcode":"110000","location":[],"address":[],"typecode":"050301"}]}`))
+	})
+	client.SetCache(NewTTLMapCache(time.Hour))
+	client.SetTipsCache(NewTTLMapCache(time.Minute))
+
+	for _, keywords := range []string{"KFC 望京", "  kfc   望京 "} {
+		resp, err := client.InputTips(&InputTipsRequest{Keywords: keywords, City: "北京", DataType: DataTypePOI})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Tips) != 2 {
+			t.Fatalf("结果数量错误: %d", len(resp.Tips))
+		}
+		if resp.Tips[0].GetLongitude() != 116.470098 {
+			t.Errorf("坐标解析错误: %s", resp.Tips[0].Location)
+		}
+		if tip := resp.Tips[1]; tip.ID != "" || tip.Location != "" || tip.Address != "" {
+			t.Errorf("空字段解析错误: %+v", tip)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("规范化后的关键字应命中缓存，实际请求 %d 次", calls)
+	}
+}
+
+func TestNormalizeKeywords(t *testing.T) {
+	tests := map[string]string{
+		"  KFC ":      "kfc",
+		"北京  大学":      "北京 大学",
+		"\tStarBucks": "starbucks",
+	}
+	for in, want := range tests {
+		if got := normalizeKeywords(in); got != want {
+			t.Errorf("normalizeKeywords(%q) = %q，期望 %q", in, got, want)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package amap
 
 import (
+	"context"
 	"encoding/json"
 	"net/url"
 )
@@ -23,6 +24,11 @@ type IPResponse struct {
 // 仅支持 IPV4，不支持国外 IP 解析。
 // https://lbs.amap.com/api/webservice/guide/api/georegeo
 func (c *Client) IP(req *IPRequest) (*IPResponse, error) {
+	return c.IPContext(context.Background(), req)
+}
+
+// IPContext 同 IP，支持通过 context 控制取消与超时
+func (c *Client) IPContext(ctx context.Context, req *IPRequest) (*IPResponse, error) {
 	params := url.Values{}
 
 	if req.IP != "" {
@@ -30,7 +36,7 @@ func (c *Client) IP(req *IPRequest) (*IPResponse, error) {
 	}
 
 	// 使用带缓存的请求
-	body, err := c.doRequestWithCache("ip", params, req)
+	body, err := c.doRequestWithCache(ctx, "ip", params, req)
 	if err != nil {
 		return nil, err
 	}
@@ -51,3 +57,8 @@ func (c *Client) IP(req *IPRequest) (*IPResponse, error) {
 func (c *Client) GetCurrentIP() (*IPResponse, error) {
 	return c.IP(&IPRequest{})
 }
+
+// GetCurrentIPContext 同 GetCurrentIP，支持通过 context 控制取消与超时
+func (c *Client) GetCurrentIPContext(ctx context.Context) (*IPResponse, error) {
+	return c.IPContext(ctx, &IPRequest{})
+}
@@ -0,0 +1,236 @@
+package amap
+
+import (
+	"errors"
+	"maps"
+	"sync"
+	"time"
+)
+
+// ErrNoAvailableKey Key 池中所有 Key 都处于隔离期
+var ErrNoAvailableKey = errors.New("amap: no available key")
+
+// defaultKeyCooldown Key 无效时的默认隔离时长
+const defaultKeyCooldown = 10 * time.Minute
+
+// KeyStats Key 的使用统计
+type KeyStats struct {
+	Key              string    // API Key
+	Weight           int       // 权重
+	Requests         int64     // 请求次数
+	Failures         int64     // 失败次数
+	Quarantines      int64     // 被隔离次数
+	QuarantinedUntil time.Time // 隔离截止时间，零值表示未隔离
+
+	// EndpointQuarantines 按接口隔离的截止时间，某个服务日配额用尽时只隔离该接口
+	EndpointQuarantines map[string]time.Time
+}
+
+// pooledKey Key 池中的 Key
+type pooledKey struct {
+	KeyStats
+	secret  string
+	current int // 平滑加权轮询的当前权重
+}
+
+// KeyPool 多 Key 池
+// 按权重平滑轮询选择 Key，权重相同时即为轮询；
+// Key 无效或余额耗尽时隔离一段时间，账号日配额用尽时隔离到北京时间次日零点；
+// 单个服务日配额用尽（如 10003）时只在该接口上隔离到次日零点，其他接口仍可使用该 Key。
+// 被隔离时请求会自动切换到其他 Key。零值可用，可直接通过 Add 添加 Key
+type KeyPool struct {
+	mu       sync.Mutex
+	keys     []*pooledKey
+	cooldown time.Duration
+
+	now func() time.Time
+}
+
+// NewKeyPool 创建 Key 池，传入的 Key 权重均为 1
+func NewKeyPool(keys ...string) *KeyPool {
+	p := &KeyPool{}
+	for _, key := range keys {
+		p.Add(key, "", 1)
+	}
+	return p
+}
+
+// Add 添加 Key，secret 为该 Key 的数字签名私钥，为空时使用 Client.Secret
+func (p *KeyPool) Add(key, secret string, weight int) *KeyPool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = append(p.keys, &pooledKey{
+		KeyStats: KeyStats{Key: key, Weight: max(weight, 1)},
+		secret:   secret,
+	})
+	return p
+}
+
+// SetCooldown 设置 Key 无效时的隔离时长，小于等于 0 时使用默认值 10 分钟
+func (p *KeyPool) SetCooldown(d time.Duration) *KeyPool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cooldown = d
+	return p
+}
+
+// Len Key 数量
+func (p *KeyPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.keys)
+}
+
+// Stats 返回各 Key 的使用统计
+func (p *KeyPool) Stats() []KeyStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]KeyStats, len(p.keys))
+	for i, k := range p.keys {
+		out[i] = k.KeyStats
+		out[i].EndpointQuarantines = maps.Clone(k.EndpointQuarantines)
+	}
+	return out
+}
+
+// next 选择下一个在 endpoint 上可用的 Key
+func (p *KeyPool) next(endpoint string) (key, secret string, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock()
+	var (
+		best  *pooledKey
+		total int
+	)
+	for _, k := range p.keys {
+		if k.quarantined(endpoint, now) {
+			continue
+		}
+		k.current += k.Weight
+		total += k.Weight
+		if best == nil || k.current > best.current {
+			best = k
+		}
+	}
+	if best == nil {
+		return "", "", ErrNoAvailableKey
+	}
+	best.current -= total
+	best.Requests++
+	return best.Key, best.secret, nil
+}
+
+// report 记录请求结果，返回该 Key 是否在 endpoint 上被隔离
+func (p *KeyPool) report(key, endpoint string, err error) bool {
+	if err == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := p.find(key)
+	if k == nil {
+		return false
+	}
+	k.Failures++
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	now := p.clock()
+	switch {
+	case apiErr.InfoCode == "10003" || apiErr.InfoCode == "10029":
+		// 单个服务的日配额用尽，只隔离该接口
+		k.quarantineEndpoint(endpoint, nextMidnight(now))
+	case apiErr.InfoCode == "10012":
+		// 无该服务的权限，只隔离该接口
+		k.quarantineEndpoint(endpoint, now.Add(p.cooldownOrDefault()))
+	case apiErr.InfoCode == "10044" || apiErr.InfoCode == "10045":
+		// 账号日配额用尽
+		k.QuarantinedUntil = nextMidnight(now)
+	case apiErr.InfoCode == "40000" || apiErr.InfoCode == "40003", IsInvalidKey(err):
+		// Key 无效或余额耗尽
+		k.QuarantinedUntil = now.Add(p.cooldownOrDefault())
+	default:
+		// IP 配额、地理围栏数量等限制与 Key 无关，不隔离
+		return false
+	}
+	k.Quarantines++
+	k.current = 0
+	return true
+}
+
+// exhaust 客户端限流器中 Key 在 endpoint 上的日配额用尽，在该接口上隔离到次日零点
+// 返回该 Key 是否存在于池中
+func (p *KeyPool) exhaust(key, endpoint string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k := p.find(key)
+	if k == nil {
+		return false
+	}
+	k.quarantineEndpoint(endpoint, nextMidnight(p.clock()))
+	k.Quarantines++
+	k.current = 0
+	return true
+}
+
+// available 是否还有在 endpoint 上未被隔离的 Key
+func (p *KeyPool) available(endpoint string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.clock()
+	for _, k := range p.keys {
+		if !k.quarantined(endpoint, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// quarantineEndpoint 在 endpoint 上隔离 Key 到 until
+func (k *pooledKey) quarantineEndpoint(endpoint string, until time.Time) {
+	if k.EndpointQuarantines == nil {
+		k.EndpointQuarantines = make(map[string]time.Time)
+	}
+	k.EndpointQuarantines[endpoint] = until
+}
+
+// quarantined Key 在 endpoint 上是否处于隔离期
+func (k *pooledKey) quarantined(endpoint string, now time.Time) bool {
+	return now.Before(k.QuarantinedUntil) || now.Before(k.EndpointQuarantines[endpoint])
+}
+
+// clock 返回当前时间，零值 KeyPool 使用 time.Now
+func (p *KeyPool) clock() time.Time {
+	if p.now == nil {
+		return time.Now()
+	}
+	return p.now()
+}
+
+// cooldownOrDefault Key 无效时的隔离时长
+func (p *KeyPool) cooldownOrDefault() time.Duration {
+	if p.cooldown <= 0 {
+		return defaultKeyCooldown
+	}
+	return p.cooldown
+}
+
+func (p *KeyPool) find(key string) *pooledKey {
+	for _, k := range p.keys {
+		if k.Key == key {
+			return k
+		}
+	}
+	return nil
+}
+
+// nextMidnight 北京时间的下一个零点
+func nextMidnight(now time.Time) time.Time {
+	t := now.In(shanghai)
+	return time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, shanghai)
+}
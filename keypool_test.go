@@ -0,0 +1,156 @@
+package amap
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestKeyPoolWeighted(t *testing.T) {
+	pool := NewKeyPool().Add("a", "", 3).Add("b", "", 1)
+
+	counts := make(map[string]int)
+	for range 8 {
+		key, _, err := pool.next("ip")
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[key]++
+	}
+	if counts["a"] != 6 || counts["b"] != 2 {
+		t.Fatalf("权重分配错误: %v", counts)
+	}
+}
+
+func TestKeyPoolFailover(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("key")
+		mu.Lock()
+		keys = append(keys, key)
+		mu.Unlock()
+		if key == "exhausted" {
+			_, _ = w.Write([]byte(`{"status":"0","info":"DAILY_QUERY_OVER_LIMIT","infocode":"10003"}`))
+			return
+		}
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	client.SetKeyPool(NewKeyPool("exhausted", "ok"))
+
+	for range 3 {
+		if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+			t.Fatalf("期望切换 Key 后成功，实际错误: %v", err)
+		}
+	}
+	// 第一次请求切换后，被隔离的 Key 不再使用
+	want := []string{"exhausted", "ok", "ok", "ok"}
+	if len(keys) != len(want) {
+		t.Fatalf("请求顺序错误: %v", keys)
+	}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("请求顺序错误: %v", keys)
+		}
+	}
+
+	stats := client.Keys.Stats()
+	if stats[0].Quarantines != 1 || stats[0].EndpointQuarantines["ip"].IsZero() {
+		t.Errorf("Key 未被隔离: %+v", stats[0])
+	}
+	if stats[1].Requests != 3 {
+		t.Errorf("Key 请求次数错误: %+v", stats[1])
+	}
+}
+
+func TestKeyPoolAllQuarantined(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","info":"INVALID_USER_KEY","infocode":"10001"}`))
+	})
+	client.SetKeyPool(NewKeyPool("a", "b").SetCooldown(time.Hour))
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); !IsInvalidKey(err) {
+		t.Fatalf("期望 Key 无效错误，实际为: %v", err)
+	}
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); !errors.Is(err, ErrNoAvailableKey) {
+		t.Fatalf("期望 ErrNoAvailableKey，实际为: %v", err)
+	}
+}
+
+func TestKeyPoolCooldownExpires(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, shanghai)
+	pool := NewKeyPool("a").SetCooldown(time.Minute)
+	pool.now = func() time.Time { return now }
+
+	pool.report("a", "ip", &APIError{InfoCode: "10001"})
+	if _, _, err := pool.next("ip"); !errors.Is(err, ErrNoAvailableKey) {
+		t.Fatalf("期望 ErrNoAvailableKey，实际为: %v", err)
+	}
+	now = now.Add(time.Minute)
+	if _, _, err := pool.next("ip"); err != nil {
+		t.Fatalf("期望隔离结束后可用，实际为: %v", err)
+	}
+
+	pool.report("a", "ip", &APIError{InfoCode: "10044"})
+	if until := pool.Stats()[0].QuarantinedUntil; !until.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, shanghai)) {
+		t.Fatalf("账号日配额用尽应隔离到次日零点，实际为: %v", until)
+	}
+}
+
+func TestKeyPoolEndpointQuarantine(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, shanghai)
+	pool := NewKeyPool("a")
+	pool.now = func() time.Time { return now }
+
+	// 单个服务日配额用尽只隔离该接口
+	if !pool.report("a", "geocode/geo", &APIError{InfoCode: "10003"}) {
+		t.Fatal("日配额用尽应隔离")
+	}
+	if until := pool.Stats()[0].EndpointQuarantines["geocode/geo"]; !until.Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, shanghai)) {
+		t.Fatalf("日配额用尽应隔离到次日零点，实际为: %v", until)
+	}
+	if _, _, err := pool.next("geocode/geo"); !errors.Is(err, ErrNoAvailableKey) {
+		t.Fatalf("期望 geocode/geo 无可用 Key，实际为: %v", err)
+	}
+	if _, _, err := pool.next("ip"); err != nil {
+		t.Fatalf("其他接口应仍可使用该 Key，实际为: %v", err)
+	}
+	if !pool.available("weather/weatherInfo") || pool.available("geocode/geo") {
+		t.Error("可用状态应按接口区分")
+	}
+
+	// IP 配额、地理围栏数量等与 Key 无关的限制不隔离
+	for _, code := range []string{"10010", "40001"} {
+		if pool.report("a", "ip", &APIError{InfoCode: code}) {
+			t.Errorf("infocode %s 不应隔离 Key", code)
+		}
+	}
+	if _, _, err := pool.next("ip"); err != nil {
+		t.Fatalf("期望 Key 可用，实际为: %v", err)
+	}
+
+	now = time.Date(2025, 1, 2, 0, 0, 0, 0, shanghai)
+	if _, _, err := pool.next("geocode/geo"); err != nil {
+		t.Fatalf("期望次日零点后恢复，实际为: %v", err)
+	}
+}
+
+func TestKeyPoolZeroValue(t *testing.T) {
+	var pool KeyPool
+	pool.Add("a", "", 1)
+
+	key, _, err := pool.next("ip")
+	if err != nil || key != "a" {
+		t.Fatalf("零值 Key 池应可用，实际为: %q %v", key, err)
+	}
+	if !pool.report("a", "ip", &APIError{InfoCode: "10001"}) {
+		t.Fatal("Key 无效应隔离")
+	}
+	if until := pool.Stats()[0].QuarantinedUntil; time.Until(until) <= 0 || time.Until(until) > defaultKeyCooldown {
+		t.Errorf("零值 Key 池应使用默认隔离时长，实际截止: %v", until)
+	}
+}
@@ -0,0 +1,83 @@
+package amap
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// stringFieldsCache 结构体类型中 string 字段的 JSON 名称
+var stringFieldsCache sync.Map // map[reflect.Type][]string
+
+// unmarshalLenient 宽松解析 JSON 对象
+// 高德在字段为空时常返回 [] 而不是 ""，对象为空时返回 [] 而不是 {}。
+// 对 v 中 string 类型的字段，数组会以 ";" 连接为字符串；整个对象为数组时保持零值。
+// v 必须是结构体指针，通常是去除了 UnmarshalJSON 方法的别名类型，避免递归。
+func unmarshalLenient(data []byte, v any) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" || trimmed == "null" || trimmed[0] == '[' {
+		return nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var changed bool
+	for _, name := range stringFields(reflect.TypeOf(v).Elem()) {
+		value, ok := raw[name]
+		if !ok || len(value) == 0 || value[0] != '[' {
+			continue
+		}
+		var items []any
+		if err := json.Unmarshal(value, &items); err != nil {
+			return err
+		}
+		parts := make([]string, 0, len(items))
+		for _, item := range items {
+			if s, ok := item.(string); ok && s != "" {
+				parts = append(parts, s)
+			}
+		}
+		raw[name], _ = json.Marshal(strings.Join(parts, ";"))
+		changed = true
+	}
+	if changed {
+		var err error
+		if data, err = json.Marshal(raw); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(data, v)
+}
+
+// stringFields 返回结构体中 string 类型字段的 JSON 名称
+func stringFields(t reflect.Type) []string {
+	if v, ok := stringFieldsCache.Load(t); ok {
+		return v.([]string)
+	}
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		// 嵌入的结构体字段展开处理
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			names = append(names, stringFields(f.Type)...)
+			continue
+		}
+		if !f.IsExported() || f.Type.Kind() != reflect.String {
+			continue
+		}
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		names = append(names, name)
+	}
+	stringFieldsCache.Store(t, names)
+	return names
+}
@@ -0,0 +1,117 @@
+package amap
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// discardLogger 未设置日志时使用，丢弃所有输出
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler 丢弃所有日志的 slog.Handler
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// logger 返回日志，未设置时丢弃输出
+func (c *Client) logger() *slog.Logger {
+	if c.Logger == nil {
+		return discardLogger
+	}
+	return c.Logger
+}
+
+// callInfo 一次 API 调用的信息，用于日志、指标与追踪
+type callInfo struct {
+	Endpoint string    // 接口
+	Start    time.Time // 开始时间
+	CacheHit bool      // 是否命中缓存
+	Attempt  Attempt   // 最后一次尝试的结果，命中缓存时为零值
+}
+
+// logCall 记录一次 API 调用
+func (c *Client) logCall(ctx context.Context, call *callInfo, err error) {
+	logger := c.logger()
+	level := slog.LevelInfo
+	if err != nil {
+		level = slog.LevelWarn
+	}
+	if !logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("endpoint", call.Endpoint),
+		slog.Bool("cache_hit", call.CacheHit),
+		slog.Duration("duration", time.Since(call.Start)),
+	}
+	if !call.CacheHit {
+		attrs = append(attrs,
+			slog.Int("attempt", call.Attempt.Number),
+			slog.Int("status", call.Attempt.StatusCode),
+			slog.String("infocode", call.Attempt.InfoCode),
+		)
+	}
+	if err != nil {
+		attrs = append(attrs, slog.Any("err", err))
+		logger.LogAttrs(ctx, level, "amap request failed", attrs...)
+		return
+	}
+	logger.LogAttrs(ctx, level, "amap request", attrs...)
+}
+
+// maxLogBodySize Debug 日志中保留的响应体最大字节数
+const maxLogBodySize = 1024
+
+// logResponse 在 Debug 级别记录请求 URL 与响应体，key 与 sig 已脱敏
+func (c *Client) logResponse(ctx context.Context, params url.Values, fullURL string, resp *Response) {
+	logger := c.logger()
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelDebug, "amap http response",
+		slog.String("url", redactURL(fullURL, params)),
+		slog.Int("status", resp.StatusCode),
+		slog.String("body", truncateBody(resp.Body, maxLogBodySize)),
+	)
+}
+
+// redactURL 将 URL 中的 key 与 sig 替换为脱敏后的值
+func redactURL(fullURL string, params url.Values) string {
+	base, _, _ := strings.Cut(fullURL, "?")
+	var b strings.Builder
+	b.WriteString(base)
+	b.WriteByte('?')
+	b.WriteString(redactParams(params).Encode())
+	if params.Has("key") {
+		b.WriteString("&key=" + maskKey(params.Get("key")))
+	}
+	if params.Has("sig") {
+		b.WriteString("&sig=***")
+	}
+	return b.String()
+}
+
+// redactURLError 脱敏 *url.Error 中的请求 URL，避免错误信息经日志、追踪泄露 key 与 sig
+func redactURLError(err error, fullURL string, params url.Values) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(fullURL, params)
+	}
+	return err
+}
+
+// maskKey 脱敏 API Key，仅保留末 4 位便于区分
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "***"
+	}
+	return "***" + key[len(key)-4:]
+}
@@ -0,0 +1,95 @@
+package amap
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStructuredLogging(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	client.SetSecret("secret")
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	for range 2 {
+		if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"endpoint=ip",
+		"cache_hit=false",
+		"cache_hit=true",
+		"status=200",
+		"infocode=10000",
+		"attempt=1",
+		"msg=\"amap http response\"",
+		"key=***-key",
+		"sig=***",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("日志缺少 %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "test-key") {
+		t.Error("日志泄露了 API Key")
+	}
+}
+
+func TestLoggingFailure(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","info":"INVALID_USER_KEY","infocode":"10001"}`))
+	})
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	_, _ = client.IP(&IPRequest{IP: "114.247.50.2"})
+
+	out := buf.String()
+	if !strings.Contains(out, "level=WARN") || !strings.Contains(out, "infocode=10001") {
+		t.Errorf("失败日志不完整:\n%s", out)
+	}
+	if strings.Contains(out, "amap http response") {
+		t.Error("Info 级别不应输出响应体")
+	}
+}
+
+func TestLoggingNetworkErrorRedacted(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	// 指向已关闭的端口，触发连接失败
+	client.BaseURL = "http://127.0.0.1:1"
+	client.APIKey = "secretkey1234"
+	client.SetSecret("secret")
+	client.SetRetryPolicy(fastRetryPolicy(2))
+	var buf bytes.Buffer
+	client.SetLogger(slog.New(slog.NewTextHandler(&buf, nil)))
+
+	_, err := client.IP(&IPRequest{IP: "114.247.50.2"})
+	if err == nil {
+		t.Fatal("连接失败应返回错误")
+	}
+
+	signature := regexp.MustCompile(`sig=[0-9a-f]{32}`)
+	out := buf.String()
+	if !strings.Contains(out, "amap request retry") || !strings.Contains(out, "amap request failed") {
+		t.Errorf("缺少重试或失败日志:\n%s", out)
+	}
+	for _, s := range []string{out, err.Error()} {
+		if strings.Contains(s, "secretkey1234") || signature.MatchString(s) {
+			t.Errorf("泄露了 key 或 sig:\n%s", s)
+		}
+		if !strings.Contains(s, "key=***1234") {
+			t.Errorf("缺少脱敏后的 key:\n%s", s)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package amap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics 指标收集接口，可对接 Prometheus 等监控系统
+type Metrics interface {
+	// ObserveRequest 记录一次实际发出的 HTTP 请求（即计费调用）及其耗时，statusCode 为 0 表示未收到响应
+	ObserveRequest(endpoint string, statusCode int, duration time.Duration)
+	// ObserveCache 记录一次缓存查询
+	ObserveCache(endpoint string, hit bool)
+	// ObserveError 记录一次失败的调用，code 为 infocode 或错误类别
+	ObserveError(endpoint, code string)
+}
+
+// NopMetrics 不做任何记录的 Metrics，为默认实现
+type NopMetrics struct{}
+
+func (NopMetrics) ObserveRequest(string, int, time.Duration) {}
+func (NopMetrics) ObserveCache(string, bool)                 {}
+func (NopMetrics) ObserveError(string, string)               {}
+
+// metrics 返回指标收集器，未设置时不记录
+func (c *Client) metrics() Metrics {
+	if c.Metrics == nil {
+		return NopMetrics{}
+	}
+	return c.Metrics
+}
+
+// errorCode 错误的分类标签，高德业务错误使用 infocode
+func errorCode(err error) string {
+	var (
+		apiErr  *APIError
+		httpErr *HTTPError
+	)
+	switch {
+	case errors.As(err, &apiErr):
+		return apiErr.InfoCode
+	case errors.As(err, &httpErr):
+		return "http_" + strconv.Itoa(httpErr.StatusCode)
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrDailyQuotaExceeded):
+		return "quota_exceeded"
+	case errors.Is(err, ErrNoAvailableKey):
+		return "no_available_key"
+	}
+	return "other"
+}
+
+// DefaultBuckets 请求耗时直方图的默认分桶，单位秒
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// labelPair 两个标签值组成的键
+type labelPair [2]string
+
+// histogram 耗时直方图
+type histogram struct {
+	counts []uint64 // 与 buckets 对应的非累计计数
+	sum    float64
+	count  uint64
+}
+
+// PrometheusMetrics 以 Prometheus 文本格式暴露指标的 Metrics 实现，无需依赖 Prometheus 客户端库
+// 本身实现了 http.Handler，可直接挂载到 /metrics
+type PrometheusMetrics struct {
+	mu        sync.Mutex
+	buckets   []float64
+	requests  map[labelPair]uint64 // endpoint, code
+	cache     map[labelPair]uint64 // endpoint, result
+	errors    map[labelPair]uint64 // endpoint, code
+	durations map[string]*histogram
+}
+
+// NewPrometheusMetrics 创建 Prometheus 指标收集器，buckets 为空时使用 DefaultBuckets
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &PrometheusMetrics{
+		buckets:   buckets,
+		requests:  make(map[labelPair]uint64),
+		cache:     make(map[labelPair]uint64),
+		errors:    make(map[labelPair]uint64),
+		durations: make(map[string]*histogram),
+	}
+}
+
+// ObserveRequest 实现 Metrics
+func (m *PrometheusMetrics) ObserveRequest(endpoint string, statusCode int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests[labelPair{endpoint, strconv.Itoa(statusCode)}]++
+
+	h, ok := m.durations[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[endpoint] = h
+	}
+	seconds := duration.Seconds()
+	if i, _ := slices.BinarySearch(m.buckets, seconds); i < len(m.buckets) {
+		h.counts[i]++
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// ObserveCache 实现 Metrics
+func (m *PrometheusMetrics) ObserveCache(endpoint string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cache[labelPair{endpoint, result}]++
+}
+
+// ObserveError 实现 Metrics
+func (m *PrometheusMetrics) ObserveError(endpoint, code string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[labelPair{endpoint, code}]++
+}
+
+// ServeHTTP 以 Prometheus 文本格式输出指标
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.Write(w)
+}
+
+// Write 以 Prometheus 文本格式写出指标
+func (m *PrometheusMetrics) Write(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	writeCounter(&b, "amap_requests_total", "Total number of HTTP requests sent to AMap.", "endpoint", "code", m.requests)
+	writeCounter(&b, "amap_cache_requests_total", "Total number of cache lookups.", "endpoint", "result", m.cache)
+	writeCounter(&b, "amap_errors_total", "Total number of failed calls by infocode.", "endpoint", "code", m.errors)
+
+	const name = "amap_request_duration_seconds"
+	fmt.Fprintf(&b, "# HELP %s HTTP request latency in seconds.\n# TYPE %s histogram\n", name, name)
+	endpoints := make([]string, 0, len(m.durations))
+	for endpoint := range m.durations {
+		endpoints = append(endpoints, endpoint)
+	}
+	slices.Sort(endpoints)
+	for _, endpoint := range endpoints {
+		h := m.durations[endpoint]
+		ep := escapeLabel(endpoint)
+		var cumulative uint64
+		for i, le := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "%s_bucket{endpoint=\"%s\",le=\"%s\"} %d\n", name, ep, strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "%s_bucket{endpoint=\"%s\",le=\"+Inf\"} %d\n", name, ep, h.count)
+		fmt.Fprintf(&b, "%s_sum{endpoint=\"%s\"} %s\n", name, ep, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "%s_count{endpoint=\"%s\"} %d\n", name, ep, h.count)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeCounter 写出带两个标签的计数器
+func writeCounter(b *strings.Builder, name, help, label1, label2 string, values map[labelPair]uint64) {
+	fmt.Fprintf(b, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+	keys := make([]labelPair, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b labelPair) int {
+		if c := strings.Compare(a[0], b[0]); c != 0 {
+			return c
+		}
+		return strings.Compare(a[1], b[1])
+	})
+	for _, k := range keys {
+		fmt.Fprintf(b, "%s{%s=\"%s\",%s=\"%s\"} %d\n", name, label1, escapeLabel(k[0]), label2, escapeLabel(k[1]), values[k])
+	}
+}
+
+// escapeLabel 转义 Prometheus 标签值
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
@@ -0,0 +1,73 @@
+package amap
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ip") == "0.0.0.0" {
+			_, _ = w.Write([]byte(`{"status":"0","info":"INVALID_PARAMS","infocode":"20000"}`))
+			return
+		}
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	metrics := NewPrometheusMetrics()
+	client.SetMetrics(metrics)
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	for range 2 {
+		if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, _ = client.IP(&IPRequest{IP: "0.0.0.0"})
+
+	rec := httptest.NewRecorder()
+	metrics.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	out := rec.Body.String()
+
+	for _, want := range []string{
+		"# TYPE amap_requests_total counter",
+		`amap_requests_total{endpoint="ip",code="200"} 2`,
+		`amap_cache_requests_total{endpoint="ip",result="hit"} 1`,
+		`amap_cache_requests_total{endpoint="ip",result="miss"} 2`,
+		`amap_errors_total{endpoint="ip",code="20000"} 1`,
+		"# TYPE amap_request_duration_seconds histogram",
+		`amap_request_duration_seconds_bucket{endpoint="ip",le="+Inf"} 2`,
+		`amap_request_duration_seconds_count{endpoint="ip"} 2`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("指标缺少 %q:\n%s", want, out)
+		}
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type 错误: %s", ct)
+	}
+}
+
+func TestHistogramBuckets(t *testing.T) {
+	metrics := NewPrometheusMetrics(0.1, 1)
+	metrics.ObserveRequest("ip", 200, 50*time.Millisecond)
+	metrics.ObserveRequest("ip", 200, 500*time.Millisecond)
+	metrics.ObserveRequest("ip", 200, 5*time.Second)
+
+	var b strings.Builder
+	if err := metrics.Write(&b); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`amap_request_duration_seconds_bucket{endpoint="ip",le="0.1"} 1`,
+		`amap_request_duration_seconds_bucket{endpoint="ip",le="1"} 2`,
+		`amap_request_duration_seconds_bucket{endpoint="ip",le="+Inf"} 3`,
+		`amap_request_duration_seconds_sum{endpoint="ip"} 5.55`,
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("指标缺少 %q:\n%s", want, b.String())
+		}
+	}
+}
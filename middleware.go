@@ -0,0 +1,67 @@
+package amap
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+// Request 中间件看到的请求
+type Request struct {
+	Endpoint string     // 接口，如 "geocode/geo"
+	Params   url.Values // 请求参数，不含 key 与 sig，中间件可以修改
+	Attempt  int        // 第几次尝试，从 1 开始
+}
+
+// Response 中间件看到的响应
+type Response struct {
+	StatusCode int          // HTTP 状态码
+	Body       []byte       // 原始响应体
+	Base       BaseResponse // 解析后的基础响应
+}
+
+// Handler 处理单次请求
+// 收到 HTTP 响应但校验失败时，会同时返回 Response 与 error
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware 中间件，包装下一个 Handler
+// 每次尝试（包括重试）都会经过中间件链，可用于日志、追踪、指标、参数改写与故障注入
+type Middleware func(next Handler) Handler
+
+// LoggingMiddleware 记录每次请求的日志
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+
+			attrs := []any{
+				"endpoint", req.Endpoint,
+				"attempt", req.Attempt,
+				"duration", time.Since(start),
+			}
+			if resp != nil {
+				attrs = append(attrs, "status", resp.StatusCode, "infocode", resp.Base.InfoCode)
+			}
+			if err != nil {
+				logger.WarnContext(ctx, "amap request failed", append(attrs, "err", err)...)
+			} else {
+				logger.InfoContext(ctx, "amap request", attrs...)
+			}
+			return resp, err
+		}
+	}
+}
+
+// TimingMiddleware 统计每次请求的耗时，fn 在请求结束后调用
+func TimingMiddleware(fn func(endpoint string, duration time.Duration, err error)) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			start := time.Now()
+			resp, err := next(ctx, req)
+			fn(req.Endpoint, time.Since(start), err)
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package amap
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrderAndRewrite(t *testing.T) {
+	var city string
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		city = r.URL.Query().Get("city")
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","count":"0","geocodes":[]}`))
+	})
+
+	var order []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, req *Request) (*Response, error) {
+				order = append(order, name)
+				if req.Params.Has("key") {
+					t.Error("中间件不应看到 key")
+				}
+				return next(ctx, req)
+			}
+		}
+	}
+	rewrite := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			req.Params.Set("city", "北京")
+			resp, err := next(ctx, req)
+			if resp == nil || resp.Base.InfoCode != "10000" {
+				t.Errorf("中间件未看到解析后的响应: %+v", resp)
+			}
+			return resp, err
+		}
+	}
+	client.Use(trace("a"), trace("b"), rewrite)
+
+	if _, err := client.Geocode(&GeocodeRequest{Address: "阜通东大街6号"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(order, ",") != "a,b" {
+		t.Errorf("中间件执行顺序错误: %v", order)
+	}
+	if city != "北京" {
+		t.Errorf("参数改写未生效: %s", city)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	client.SetRetryPolicy(fastRetryPolicy(3))
+
+	// 第一次尝试注入 QPS 超限错误
+	client.Use(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			if req.Attempt == 1 {
+				return nil, &APIError{Status: "0", InfoCode: "10019"}
+			}
+			return next(ctx, req)
+		}
+	})
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatalf("期望重试后成功，实际错误: %v", err)
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("期望实际请求 1 次，实际 %d 次", n)
+	}
+}
+
+func TestBuiltinMiddlewares(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+
+	var buf bytes.Buffer
+	var timed time.Duration
+	client.Use(
+		LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil))),
+		TimingMiddleware(func(endpoint string, d time.Duration, err error) {
+			if endpoint != "ip" || err != nil {
+				t.Errorf("统计信息错误: %s %v", endpoint, err)
+			}
+			timed = d
+		}),
+	)
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "endpoint=ip") {
+		t.Errorf("日志缺少接口信息: %s", buf.String())
+	}
+	if timed <= 0 {
+		t.Error("未统计耗时")
+	}
+}
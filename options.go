@@ -0,0 +1,116 @@
+package amap
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Option 客户端配置项
+type Option func(*Client)
+
+// WithBaseURL 设置API基础URL，默认为 BaseURL
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.BaseURL = baseURL
+	}
+}
+
+// WithHTTPClient 设置 HTTP 客户端，会替换默认的超时与连接池配置
+// 与 WithTimeout、WithTransport 同时使用时不会修改传入的客户端
+func WithHTTPClient(cli *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = cli
+	}
+}
+
+// WithTransport 设置 HTTP 客户端的 Transport，与 Option 的顺序无关
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpOpts.transport = rt
+	}
+}
+
+// WithTimeout 设置 HTTP 请求超时时间，默认 10 秒，与 Option 的顺序无关
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpOpts.timeout = &timeout
+	}
+}
+
+// WithCache 设置缓存
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.Cache = cache
+	}
+}
+
+// WithTipsCache 设置输入提示专用缓存
+func WithTipsCache(cache Cache) Option {
+	return func(c *Client) {
+		c.TipsCache = cache
+	}
+}
+
+// WithLogger 设置日志
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.Logger = logger
+	}
+}
+
+// WithRetry 设置重试策略
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = policy
+	}
+}
+
+// WithRateLimiter 设置客户端限流器
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.Limiter = limiter
+	}
+}
+
+// WithUserAgent 设置请求头 User-Agent
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithSecret 设置数字签名私钥
+func WithSecret(secret string) Option {
+	return func(c *Client) {
+		c.Secret = secret
+	}
+}
+
+// WithKeyPool 设置多 Key 池
+func WithKeyPool(pool *KeyPool) Option {
+	return func(c *Client) {
+		c.Keys = pool
+	}
+}
+
+// WithMiddleware 添加中间件
+func WithMiddleware(mws ...Middleware) Option {
+	return func(c *Client) {
+		c.Use(mws...)
+	}
+}
+
+// WithMetrics 设置指标收集器
+func WithMetrics(metrics Metrics) Option {
+	return func(c *Client) {
+		c.Metrics = metrics
+	}
+}
+
+// WithTracer 设置链路追踪
+func WithTracer(tracer Tracer) Option {
+	return func(c *Client) {
+		c.Tracer = tracer
+	}
+}
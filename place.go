@@ -0,0 +1,352 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PlaceResponse 搜索POI响应
+type PlaceResponse struct {
+	BaseResponse
+	Count      string     `json:"count"`      // 搜索结果总数，最多返回 1000 条
+	Suggestion Suggestion `json:"suggestion"` // 城市建议，仅在关键字搜索且无结果时返回
+	Pois       []POI      `json:"pois"`       // POI信息列表
+}
+
+// Total 搜索结果总数
+func (r *PlaceResponse) Total() int {
+	n, _ := strconv.Atoi(r.Count)
+	return n
+}
+
+// Suggestion 城市建议
+type Suggestion struct {
+	Keywords []string         `json:"keywords"` // 关键字建议列表
+	Cities   []SuggestionCity `json:"cities"`   // 城市建议列表
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况
+func (s *Suggestion) UnmarshalJSON(data []byte) error {
+	type alias Suggestion
+	return unmarshalLenient(data, (*alias)(s))
+}
+
+// SuggestionCity 建议城市
+type SuggestionCity struct {
+	Name     string `json:"name"`     // 名称
+	Num      string `json:"num"`      // 该城市包含此关键字的个数
+	CityCode string `json:"citycode"` // 城市编码
+	AdCode   string `json:"adcode"`   // 区域编码
+}
+
+// PlaceTextRequest 关键字搜索请求参数
+type PlaceTextRequest struct {
+	Keywords   string   // 查询关键字，与 Types 至少填写一个
+	Types      []string // POI类型，可选，支持分类名称或分类编码
+	City       string   // 查询城市，可选，支持城市中文、citycode、adcode
+	CityLimit  bool     // 是否仅返回指定城市的数据
+	Children   bool     // 是否按层级展示子POI
+	Offset     int      // 每页记录数，默认 20，建议不超过 25
+	Page       int      // 当前页数，默认 1
+	Extensions string   // 返回结果控制：base(默认) 或 all
+}
+
+// PlaceText 关键字搜索 - 根据关键字与类型搜索POI
+// https://lbs.amap.com/api/webservice/guide/api/search
+func (c *Client) PlaceText(req *PlaceTextRequest) (*PlaceResponse, error) {
+	return c.PlaceTextContext(context.Background(), req)
+}
+
+// PlaceTextContext 同 PlaceText，支持通过 context 控制取消与超时
+func (c *Client) PlaceTextContext(ctx context.Context, req *PlaceTextRequest) (*PlaceResponse, error) {
+	if req.Keywords == "" && len(req.Types) == 0 {
+		return nil, fmt.Errorf("%w: keywords 与 types 至少填写一个", ErrInvalidParams)
+	}
+
+	params := url.Values{}
+	if req.Keywords != "" {
+		params.Set("keywords", req.Keywords)
+	}
+
+	if len(req.Types) > 0 {
+		params.Set("types", strings.Join(req.Types, "|"))
+	}
+
+	if req.City != "" {
+		params.Set("city", req.City)
+	}
+
+	if req.CityLimit {
+		params.Set("citylimit", "true")
+	}
+
+	if req.Children {
+		params.Set("children", "1")
+	}
+
+	setPaging(params, req.Offset, req.Page)
+
+	if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	return c.doPlaceRequest(ctx, "place/text", params, req)
+}
+
+// 周边搜索排序规则
+const (
+	SortByDistance = "distance" // 按距离排序
+	SortByWeight   = "weight"   // 综合排序
+)
+
+// PlaceAroundRequest 周边搜索请求参数
+type PlaceAroundRequest struct {
+	Location   string   // 中心点坐标，必填 "经度,纬度"
+	Keywords   string   // 查询关键字，可选
+	Types      []string // POI类型，可选
+	City       string   // 查询城市，可选
+	Radius     int      // 查询半径（米），取值 0-50000，默认 5000
+	SortRule   string   // 排序规则：SortByDistance(默认) 或 SortByWeight
+	Offset     int      // 每页记录数，默认 20，建议不超过 25
+	Page       int      // 当前页数，默认 1
+	Extensions string   // 返回结果控制：base(默认) 或 all
+}
+
+// PlaceAround 周边搜索 - 搜索中心点附近的POI，可通过 POI.GetDistance 获取距离
+// https://lbs.amap.com/api/webservice/guide/api/search
+func (c *Client) PlaceAround(req *PlaceAroundRequest) (*PlaceResponse, error) {
+	return c.PlaceAroundContext(context.Background(), req)
+}
+
+// PlaceAroundContext 同 PlaceAround，支持通过 context 控制取消与超时
+func (c *Client) PlaceAroundContext(ctx context.Context, req *PlaceAroundRequest) (*PlaceResponse, error) {
+	if req.Location == "" {
+		return nil, fmt.Errorf("%w: location 不能为空", ErrInvalidParams)
+	}
+
+	params := url.Values{}
+	params.Set("location", req.Location)
+
+	if req.Keywords != "" {
+		params.Set("keywords", req.Keywords)
+	}
+
+	if len(req.Types) > 0 {
+		params.Set("types", strings.Join(req.Types, "|"))
+	}
+
+	if req.City != "" {
+		params.Set("city", req.City)
+	}
+
+	if req.Radius > 0 {
+		params.Set("radius", strconv.Itoa(req.Radius))
+	}
+
+	if req.SortRule != "" {
+		params.Set("sortrule", req.SortRule)
+	}
+
+	setPaging(params, req.Offset, req.Page)
+
+	if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	return c.doPlaceRequest(ctx, "place/around", params, req)
+}
+
+// PlacePolygonRequest 多边形搜索请求参数
+type PlacePolygonRequest struct {
+	// Polygon 搜索区域，必填
+	// 2 个坐标表示矩形的左上与右下顶点；多边形至少 4 个坐标，且首尾坐标相同
+	Polygon    []Coordinate
+	Keywords   string   // 查询关键字，可选
+	Types      []string // POI类型，可选
+	Offset     int      // 每页记录数，默认 20，建议不超过 25
+	Page       int      // 当前页数，默认 1
+	Extensions string   // 返回结果控制：base(默认) 或 all
+}
+
+// validatePolygon 校验多边形，矩形为 2 个顶点，多边形需闭合且至少 3 个不同顶点
+func validatePolygon(polygon []Coordinate) error {
+	switch n := len(polygon); {
+	case n == 2:
+		return nil
+	case n < 4:
+		return fmt.Errorf("%w: polygon 需为 2 个坐标的矩形或至少 4 个坐标的闭合多边形，实际 %d 个", ErrInvalidParams, n)
+	case polygon[0] != polygon[n-1]:
+		return fmt.Errorf("%w: polygon 首尾坐标不同，多边形未闭合", ErrInvalidParams)
+	}
+	distinct := make(map[Coordinate]struct{}, len(polygon)-1)
+	for _, p := range polygon[:len(polygon)-1] {
+		distinct[p] = struct{}{}
+	}
+	if len(distinct) < 3 {
+		return fmt.Errorf("%w: polygon 至少需要 3 个不同顶点，实际 %d 个", ErrInvalidParams, len(distinct))
+	}
+	return nil
+}
+
+// PlacePolygon 多边形搜索 - 搜索多边形或矩形区域内的POI
+// https://lbs.amap.com/api/webservice/guide/api/search
+func (c *Client) PlacePolygon(req *PlacePolygonRequest) (*PlaceResponse, error) {
+	return c.PlacePolygonContext(context.Background(), req)
+}
+
+// PlacePolygonContext 同 PlacePolygon，支持通过 context 控制取消与超时
+func (c *Client) PlacePolygonContext(ctx context.Context, req *PlacePolygonRequest) (*PlaceResponse, error) {
+	if err := validatePolygon(req.Polygon); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("polygon", joinCoordinates(req.Polygon, "|"))
+
+	if req.Keywords != "" {
+		params.Set("keywords", req.Keywords)
+	}
+
+	if len(req.Types) > 0 {
+		params.Set("types", strings.Join(req.Types, "|"))
+	}
+
+	setPaging(params, req.Offset, req.Page)
+
+	if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	return c.doPlaceRequest(ctx, "place/polygon", params, req)
+}
+
+// maxPlaceDetailIDs 单次 ID 查询最多支持的 POI ID 数量
+const maxPlaceDetailIDs = 10
+
+// PlaceDetailRequest ID查询请求参数
+type PlaceDetailRequest struct {
+	IDs []string // POI ID 列表，必填，超过 10 个时自动拆分为多次请求
+}
+
+// PlaceDetail ID查询 - 根据POI ID查询POI详细信息
+// 结果按 ID 单独缓存，不同批次中的相同 ID 也能命中缓存；返回的 POI 顺序与 IDs 一致，未查询到的 ID 会被忽略
+// https://lbs.amap.com/api/webservice/guide/api/search
+func (c *Client) PlaceDetail(req *PlaceDetailRequest) (*PlaceResponse, error) {
+	return c.PlaceDetailContext(context.Background(), req)
+}
+
+// PlaceDetailContext 同 PlaceDetail，支持通过 context 控制取消与超时
+func (c *Client) PlaceDetailContext(ctx context.Context, req *PlaceDetailRequest) (*PlaceResponse, error) {
+	if len(req.IDs) == 0 {
+		return nil, fmt.Errorf("%w: ids 不能为空", ErrInvalidParams)
+	}
+
+	pois := make(map[string]POI, len(req.IDs))
+	missing := make([]string, 0, len(req.IDs))
+	for _, id := range req.IDs {
+		if _, ok := pois[id]; ok || slices.Contains(missing, id) {
+			continue
+		}
+		poi, found, err := c.cachedPOI(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			pois[id] = poi
+			continue
+		}
+		missing = append(missing, id)
+	}
+
+	for ids := range slices.Chunk(missing, maxPlaceDetailIDs) {
+		params := url.Values{}
+		params.Set("id", strings.Join(ids, "|"))
+
+		// 按 ID 缓存，不缓存整批结果
+		resp, err := c.doPlaceRequest(ctx, "place/detail", params, nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, poi := range resp.Pois {
+			pois[poi.ID] = poi
+			c.cachePOI(ctx, poi)
+		}
+	}
+
+	resp := PlaceResponse{
+		BaseResponse: BaseResponse{Status: "1", Info: "OK", InfoCode: "10000"},
+		Pois:         make([]POI, 0, len(req.IDs)),
+	}
+	for _, id := range req.IDs {
+		if poi, ok := pois[id]; ok {
+			resp.Pois = append(resp.Pois, poi)
+		}
+	}
+	resp.Count = strconv.Itoa(len(resp.Pois))
+	return &resp, nil
+}
+
+// cachedPOI 从缓存读取 POI 详情
+func (c *Client) cachedPOI(ctx context.Context, id string) (POI, bool, error) {
+	var poi POI
+	if c.Cache == nil {
+		return poi, false, nil
+	}
+	data, found, err := cacheGet(ctx, c.Cache, generateCacheKey("place/detail", id))
+	if err != nil {
+		return poi, false, err
+	}
+	if found && json.Unmarshal(data, &poi) != nil {
+		found = false
+	}
+	c.metrics().ObserveCache("place/detail", found)
+	return poi, found, nil
+}
+
+// cachePOI 按 ID 缓存 POI 详情
+func (c *Client) cachePOI(ctx context.Context, poi POI) {
+	if c.Cache == nil || poi.ID == "" {
+		return
+	}
+	data, err := json.Marshal(poi)
+	if err != nil {
+		return
+	}
+	cacheSet(ctx, c.Cache, generateCacheKey("place/detail", poi.ID), data)
+}
+
+// setPaging 设置分页参数
+func setPaging(params url.Values, offset, page int) {
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+
+	if page > 0 {
+		params.Set("page", strconv.Itoa(page))
+	}
+}
+
+// doPlaceRequest 执行搜索POI请求
+func (c *Client) doPlaceRequest(ctx context.Context, endpoint string, params url.Values, cacheKeyParams interface{}) (*PlaceResponse, error) {
+	// 使用带缓存的请求
+	body, err := c.doRequestWithCache(ctx, endpoint, params, cacheKeyParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp PlaceResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,250 @@
+package amap
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const placeTextJSON = `{"status":"1","count":"2","info":"OK","infocode":"10000","suggestion":{"keywords":[],"cities":[]},"pois":[{"parent":[],"address":"阜通东大街6号","distance":[],"pname":"北京市","importance":[],"biz_ext":{"cost":"58.00","rating":"4.5","meal_ordering":"0"},"biz_type":"diner","cityname":"北京市","type":"餐饮服务;中餐厅;中餐厅","photos":[{"title":[],"url":"http://store.is.autonavi.com/showpic/1.jpg"}],"typecode":"050100","shopinfo":"0","poiweight":[],"childtype":[],"adname":"朝阳区","name":"望京SOHO餐厅","location":"116.480983,39.989628","tel":["010-12345678","010-87654321"],"shopid":[],"id":"B0FFFAB6J2","business_area":"望京","indoor_map":"1","indoor_data":{"cpid":"B0FFFAB6J2","floor":"1","truefloor":"F1","cmsid":[]}},{"parent":[],"address":[],"distance":[],"pname":"北京市","biz_ext":[],"cityname":"北京市","type":"餐饮服务","photos":[],"typecode":"050000","adname":"朝阳区","name":"测试","location":"116.48,39.98","tel":[],"id":"B000000002","business_area":[],"indoor_map":"0","indoor_data":{"cpid":[],"floor":[],"truefloor":[],"cmsid":[]}}]}`
+
+func TestPlaceTextJSONParsing(t *testing.T) {
+	var resp PlaceResponse
+	if err := json.Unmarshal([]byte(placeTextJSON), &resp); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+	if resp.Total() != 2 || len(resp.Pois) != 2 {
+		t.Fatalf("结果数量错误: %s %d", resp.Count, len(resp.Pois))
+	}
+
+	poi := resp.Pois[0]
+	if poi.Tel != "010-12345678;010-87654321" {
+		t.Errorf("电话解析错误: %s", poi.Tel)
+	}
+	if poi.BusinessArea != "望京" {
+		t.Errorf("商圈解析错误: %s", poi.BusinessArea)
+	}
+	if poi.BizExt == nil || poi.BizExt.Rating != "4.5" || poi.BizExt.Cost != "58.00" {
+		t.Errorf("深度信息解析错误: %+v", poi.BizExt)
+	}
+	if len(poi.Photos) != 1 || poi.Photos[0].Title != "" || poi.Photos[0].URL == "" {
+		t.Errorf("照片解析错误: %+v", poi.Photos)
+	}
+	if poi.IndoorData == nil || poi.IndoorData.TrueFloor != "F1" {
+		t.Errorf("室内信息解析错误: %+v", poi.IndoorData)
+	}
+
+	empty := resp.Pois[1]
+	if empty.Address != "" || empty.Tel != "" || empty.BusinessArea != "" {
+		t.Errorf("空字段解析错误: %+v", empty)
+	}
+	if empty.BizExt == nil || *empty.BizExt != (BizExt{}) {
+		t.Errorf("空深度信息解析错误: %+v", empty.BizExt)
+	}
+}
+
+func TestPlaceText(t *testing.T) {
+	var query map[string]string
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/place/text" {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		query = make(map[string]string)
+		for k := range r.URL.Query() {
+			query[k] = r.URL.Query().Get(k)
+		}
+		_, _ = w.Write([]byte(placeTextJSON))
+	})
+
+	resp, err := client.PlaceText(&PlaceTextRequest{
+		Keywords:   "餐厅",
+		Types:      []string{"050000", "060000"},
+		City:       "北京",
+		CityLimit:  true,
+		Children:   true,
+		Offset:     10,
+		Page:       2,
+		Extensions: "all",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Pois) != 2 {
+		t.Fatalf("结果数量错误: %d", len(resp.Pois))
+	}
+
+	want := map[string]string{
+		"keywords":   "餐厅",
+		"types":      "050000|060000",
+		"city":       "北京",
+		"citylimit":  "true",
+		"children":   "1",
+		"offset":     "10",
+		"page":       "2",
+		"extensions": "all",
+	}
+	for k, v := range want {
+		if query[k] != v {
+			t.Errorf("参数 %s 期望 %s，实际 %s", k, v, query[k])
+		}
+	}
+}
+
+func TestPlaceTextValidation(t *testing.T) {
+	client := NewClient("test-key")
+	if _, err := client.PlaceText(&PlaceTextRequest{City: "北京"}); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("期望 ErrInvalidParams，实际为: %v", err)
+	}
+}
+
+func TestPlaceAround(t *testing.T) {
+	var calls int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/place/around" || q.Get("location") != "116.473168,39.993015" ||
+			q.Get("radius") != "1000" || q.Get("sortrule") != SortByDistance || q.Get("types") != "050000" {
+			t.Errorf("请求参数错误: %s", r.URL.String())
+		}
+		_, _ = w.Write([]byte(`{"status":"1","count":"1","info":"OK","infocode":"10000","suggestion":[],"pois":[{"id":"B0FFFAB6J2","name":"望京SOHO","location":"116.480983,39.989628","distance":"732"}]}`))
+	})
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	req := &PlaceAroundRequest{
+		Location: "116.473168,39.993015",
+		Types:    []string{"050000"},
+		Radius:   1000,
+		SortRule: SortByDistance,
+	}
+	for range 2 {
+		resp, err := client.PlaceAround(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		poi := resp.Pois[0]
+		if poi.DistanceMeters != 732 || poi.GetDistance() != 732 {
+			t.Errorf("距离解析错误: %v", poi.DistanceMeters)
+		}
+		if poi.GetLongitude() != 116.480983 || poi.GetLatitude() != 39.989628 {
+			t.Errorf("坐标解析错误: %s", poi.Location)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("期望请求 1 次，实际 %d 次", calls)
+	}
+
+	if _, err := client.PlaceAround(&PlaceAroundRequest{Keywords: "咖啡"}); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("期望 ErrInvalidParams，实际为: %v", err)
+	}
+}
+
+func TestPlacePolygon(t *testing.T) {
+	var polygon string
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/place/polygon" {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		polygon = r.URL.Query().Get("polygon")
+		_, _ = w.Write([]byte(`{"status":"1","count":"0","info":"OK","infocode":"10000","pois":[]}`))
+	})
+
+	ring := []Coordinate{
+		{Lng: 116.460988, Lat: 40.006919},
+		{Lng: 116.48231, Lat: 40.007381},
+		{Lng: 116.47516, Lat: 39.99713},
+		{Lng: 116.460988, Lat: 40.006919},
+	}
+	if _, err := client.PlacePolygon(&PlacePolygonRequest{Polygon: ring, Keywords: "肯德基"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "116.460988,40.006919|116.48231,40.007381|116.47516,39.99713|116.460988,40.006919"; polygon != want {
+		t.Errorf("polygon 参数错误: %s", polygon)
+	}
+
+	rect := []Coordinate{{Lng: 116.460988, Lat: 40.006919}, {Lng: 116.48231, Lat: 39.99713}}
+	if _, err := client.PlacePolygon(&PlacePolygonRequest{Polygon: rect}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlacePolygonValidation(t *testing.T) {
+	client := NewClient("test-key")
+	tests := [][]Coordinate{
+		nil,
+		{{Lng: 116.46, Lat: 40.00}},
+		{{Lng: 116.46, Lat: 40.00}, {Lng: 116.48, Lat: 40.00}, {Lng: 116.46, Lat: 40.00}},
+		{{Lng: 116.46, Lat: 40.00}, {Lng: 116.48, Lat: 40.00}, {Lng: 116.47, Lat: 39.99}, {Lng: 116.45, Lat: 39.99}},
+		{{Lng: 116.46, Lat: 40.00}, {Lng: 116.46, Lat: 40.00}, {Lng: 116.46, Lat: 40.00}, {Lng: 116.46, Lat: 40.00}},
+		{{Lng: 116.46, Lat: 40.00}, {Lng: 116.48, Lat: 40.00}, {Lng: 116.48, Lat: 40.00}, {Lng: 116.46, Lat: 40.00}},
+	}
+	for _, polygon := range tests {
+		if _, err := client.PlacePolygon(&PlacePolygonRequest{Polygon: polygon}); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("polygon %v 期望 ErrInvalidParams，实际为: %v", polygon, err)
+		}
+	}
+}
+
+func TestPlaceDetailCachedPerID(t *testing.T) {
+	var requested []string
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("id")
+		requested = append(requested, ids)
+		var pois []string
+		for _, id := range strings.Split(ids, "|") {
+			if id == "UNKNOWN" {
+				continue
+			}
+			pois = append(pois, `{"id":"`+id+`","name":"POI `+id+`","biz_ext":{"rating":"4.8"}}`)
+		}
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","count":"` + strconv.Itoa(len(pois)) + `","pois":[` + strings.Join(pois, ",") + `]}`))
+	})
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	resp, err := client.PlaceDetail(&PlaceDetailRequest{IDs: []string{"A", "B", "UNKNOWN"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Pois) != 2 || resp.Pois[0].ID != "A" || resp.Pois[1].ID != "B" {
+		t.Fatalf("结果错误: %+v", resp.Pois)
+	}
+
+	// B 命中缓存，只请求 C
+	resp, err = client.PlaceDetail(&PlaceDetailRequest{IDs: []string{"C", "B"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Pois) != 2 || resp.Pois[0].ID != "C" || resp.Pois[1].ID != "B" {
+		t.Fatalf("结果顺序错误: %+v", resp.Pois)
+	}
+	if resp.Pois[1].BizExt == nil || resp.Pois[1].BizExt.Rating != "4.8" {
+		t.Errorf("缓存的详情不完整: %+v", resp.Pois[1])
+	}
+
+	want := []string{"A|B|UNKNOWN", "C"}
+	if strings.Join(requested, ",") != strings.Join(want, ",") {
+		t.Errorf("请求批次错误: %v", requested)
+	}
+}
+
+func TestPlaceDetailChunked(t *testing.T) {
+	var batches []int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		batches = append(batches, len(strings.Split(r.URL.Query().Get("id"), "|")))
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","count":"0","pois":[]}`))
+	})
+
+	ids := make([]string, 23)
+	for i := range ids {
+		ids[i] = "B" + strconv.Itoa(i)
+	}
+	if _, err := client.PlaceDetail(&PlaceDetailRequest{IDs: ids}); err != nil {
+		t.Fatal(err)
+	}
+	if len(batches) != 3 || batches[0] != 10 || batches[2] != 3 {
+		t.Errorf("拆分批次错误: %v", batches)
+	}
+}
@@ -0,0 +1,453 @@
+package amap
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRateLimited 超出客户端限流，仅在 FailFast 模式下返回
+	ErrRateLimited = errors.New("amap: rate limited")
+	// ErrDailyQuotaExceeded 超出当日调用配额
+	ErrDailyQuotaExceeded = errors.New("amap: daily quota exceeded")
+)
+
+// shanghai 高德配额按北京时间零点重置，中国不使用夏令时，固定时区即可
+var shanghai = time.FixedZone("Asia/Shanghai", 8*60*60)
+
+// QuotaStore 配额计数的持久化接口，用户可以自定义实现（如Redis等）
+// Load 与 Save 在限流器的锁内调用，Save 每次请求都会调用，实现应避免阻塞，可先写入内存再异步持久化
+type QuotaStore interface {
+	// Load 读取某天某接口的已用次数，每个接口每天只在首次使用时调用
+	// 使用 Key 池时 endpoint 为 "<Key 的 SHA-256 摘要前 16 位>:<接口>" 形式，按 Key 分别计数且不保存原始 Key；
+	// 返回错误时记录警告并从 0 开始计数，不影响请求
+	Load(day, endpoint string) (int64, error)
+	// Save 保存某天某接口的已用次数
+	Save(day, endpoint string, count int64) error
+}
+
+// RateLimiter 客户端限流器
+// 按接口使用令牌桶限制 QPS，并可按接口限制每日调用次数；零值可用，表示不限制 QPS。
+// 高德的 QPS 与日配额按 Key 计算，Client 使用 Key 池时每个 Key 分别限流与计数，
+// 即配置的 QPS 与日配额对池中每个 Key 单独生效
+type RateLimiter struct {
+	mu          sync.Mutex
+	defaultQPS  float64
+	endpointQPS map[string]float64
+	buckets     map[limitKey]*tokenBucket
+	failFast    bool
+
+	quotaLimits map[string]int64 // 空字符串表示所有接口合计
+	quotas      map[limitKey]*dailyQuota
+	exhausted   map[limitKey]string // 服务端返回配额超限的日期
+	store       QuotaStore
+
+	now func() time.Time
+}
+
+// NewRateLimiter 创建限流器，qps 为每个接口的默认 QPS，小于等于 0 表示不限制
+func NewRateLimiter(qps float64) *RateLimiter {
+	return &RateLimiter{defaultQPS: qps}
+}
+
+// SetEndpointQPS 设置指定接口的 QPS，如 "geocode/geo"、"ip"
+func (l *RateLimiter) SetEndpointQPS(endpoint string, qps float64) *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	setEntry(&l.endpointQPS, endpoint, qps)
+	for k := range l.buckets {
+		if k.endpoint == endpoint {
+			delete(l.buckets, k)
+		}
+	}
+	return l
+}
+
+// SetFailFast 设置超出 QPS 时直接返回 ErrRateLimited，而不是等待
+func (l *RateLimiter) SetFailFast(failFast bool) *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.failFast = failFast
+	return l
+}
+
+// SetDailyQuota 设置指定接口的每日调用上限，endpoint 为空表示所有接口合计
+// 计数在北京时间零点重置
+func (l *RateLimiter) SetDailyQuota(endpoint string, limit int64) *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	setEntry(&l.quotaLimits, endpoint, limit)
+	return l
+}
+
+// SetQuotaStore 设置配额计数的持久化存储
+func (l *RateLimiter) SetQuotaStore(store QuotaStore) *RateLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.store = store
+	l.quotas = nil
+	return l
+}
+
+// DailyUsage 返回指定接口当日已用次数，endpoint 为空表示所有接口合计
+// 仅统计未使用 Key 池时的调用，Key 池中各 Key 的用量见 KeyDailyUsage
+func (l *RateLimiter) DailyUsage(endpoint string) int64 {
+	return l.KeyDailyUsage("", endpoint)
+}
+
+// KeyDailyUsage 返回 Key 池中指定 Key 在指定接口的当日已用次数，endpoint 为空表示该 Key 所有接口合计
+func (l *RateLimiter) KeyDailyUsage(key, endpoint string) int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q, _ := l.quota(limitKey{key, endpoint}, l.today())
+	return q.count
+}
+
+// Wait 等待指定接口的令牌并占用当日配额
+func (l *RateLimiter) Wait(ctx context.Context, endpoint string) error {
+	return l.wait(ctx, discardLogger, "", endpoint)
+}
+
+// wait 等待 Key 在指定接口的令牌并占用当日配额，key 为空表示未使用 Key 池
+// 配额存储读取失败时通过 logger 记录警告
+func (l *RateLimiter) wait(ctx context.Context, logger *slog.Logger, key, endpoint string) error {
+	l.mu.Lock()
+	bucket := l.bucket(limitKey{key, endpoint})
+	failFast := l.failFast
+	l.mu.Unlock()
+
+	if bucket != nil {
+		delay, ok := bucket.reserve(l.clock(), failFast)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrRateLimited, endpoint)
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			bucket.cancel()
+			return err
+		}
+	}
+	return l.acquireQuota(ctx, logger, key, endpoint)
+}
+
+// exhaust 收到高德配额超限的响应后，将 Key 在该接口的当日配额标记为用尽
+// 高德的日配额按服务计算，不影响其他接口与合计配额
+func (l *RateLimiter) exhaust(key, endpoint string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	day := l.today()
+	k := limitKey{key, endpoint}
+	setEntry(&l.exhausted, k, day)
+	if limit, ok := l.quotaLimits[endpoint]; ok {
+		q, _ := l.quota(k, day)
+		q.count = limit
+		l.save(day, k, q.count)
+	}
+}
+
+// acquireQuota 占用 Key 的一次当日配额
+func (l *RateLimiter) acquireQuota(ctx context.Context, logger *slog.Logger, key, endpoint string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	day := l.today()
+	if l.exhausted[limitKey{key, endpoint}] == day {
+		return fmt.Errorf("%w: %s", ErrDailyQuotaExceeded, endpoint)
+	}
+	acquired := make([]limitKey, 0, 2)
+	for _, name := range []string{endpoint, ""} {
+		limit, ok := l.quotaLimits[name]
+		if !ok {
+			continue
+		}
+		k := limitKey{key, name}
+		q, err := l.quota(k, day)
+		if err != nil {
+			logger.WarnContext(ctx, "amap quota store load failed, counting from 0", "endpoint", endpoint, "err", err)
+		}
+		if q.count >= limit {
+			return fmt.Errorf("%w: %s", ErrDailyQuotaExceeded, endpoint)
+		}
+		acquired = append(acquired, k)
+	}
+	for _, k := range acquired {
+		q := l.quotas[k]
+		q.count++
+		l.save(day, k, q.count)
+	}
+	return nil
+}
+
+// quota 获取当日配额计数，跨天时重新加载
+// 从存储加载失败时返回错误，同时返回从 0 开始的计数，请求不受影响
+func (l *RateLimiter) quota(k limitKey, day string) (*dailyQuota, error) {
+	q, ok := l.quotas[k]
+	if ok && q.day == day {
+		return q, nil
+	}
+	q = &dailyQuota{day: day}
+	setEntry(&l.quotas, k, q)
+	if l.store == nil {
+		return q, nil
+	}
+	count, err := l.store.Load(day, k.String())
+	if err != nil {
+		return q, fmt.Errorf("load quota err: %w", err)
+	}
+	q.count = count
+	return q, nil
+}
+
+// save 持久化配额计数，存储失败不影响请求
+func (l *RateLimiter) save(day string, k limitKey, count int64) {
+	if l.store != nil {
+		_ = l.store.Save(day, k.String(), count)
+	}
+}
+
+// clock 返回当前时间，零值 RateLimiter 使用 time.Now
+func (l *RateLimiter) clock() time.Time {
+	if l.now == nil {
+		return time.Now()
+	}
+	return l.now()
+}
+
+// today 北京时间的当日日期
+func (l *RateLimiter) today() string {
+	return l.clock().In(shanghai).Format(time.DateOnly)
+}
+
+// bucket 获取 Key 在接口上的令牌桶，不限流时返回 nil
+func (l *RateLimiter) bucket(k limitKey) *tokenBucket {
+	if b, ok := l.buckets[k]; ok {
+		return b
+	}
+	qps, ok := l.endpointQPS[k.endpoint]
+	if !ok {
+		qps = l.defaultQPS
+	}
+	var b *tokenBucket
+	if qps > 0 {
+		b = newTokenBucket(qps, l.clock())
+	}
+	setEntry(&l.buckets, k, b)
+	return b
+}
+
+// setEntry 写入 map，map 为 nil 时先初始化，使 RateLimiter 的零值可用
+func setEntry[K comparable, V any](m *map[K]V, key K, value V) {
+	if *m == nil {
+		*m = make(map[K]V)
+	}
+	(*m)[key] = value
+}
+
+// limitKey 限流与配额的计数维度，key 为空表示未使用 Key 池，endpoint 为空表示所有接口合计
+type limitKey struct {
+	key      string
+	endpoint string
+}
+
+// String 配额存储中使用的名称，未使用 Key 池时即为接口名
+// 使用 Key 池时以 Key 的摘要区分，避免原始 Key 写入存储
+func (k limitKey) String() string {
+	if k.key == "" {
+		return k.endpoint
+	}
+	sum := sha256.Sum256([]byte(k.key))
+	return hex.EncodeToString(sum[:8]) + ":" + k.endpoint
+}
+
+// dailyQuota 当日配额计数
+type dailyQuota struct {
+	day   string
+	count int64
+}
+
+// tokenBucket 令牌桶
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // 每秒生成的令牌数
+	burst  float64 // 桶容量
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(qps float64, now time.Time) *tokenBucket {
+	burst := math.Max(1, math.Ceil(qps))
+	return &tokenBucket{rate: qps, burst: burst, tokens: burst, last: now}
+}
+
+// reserve 预占一个令牌，返回需要等待的时间
+// failFast 为 true 且没有可用令牌时返回 false，不占用令牌
+func (b *tokenBucket) reserve(now time.Time, failFast bool) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+	if failFast && b.tokens < 1 {
+		return 0, false
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0, true
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second)), true
+}
+
+// cancel 归还预占的令牌
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+// fileQuotaFlushInterval FileQuotaStore 合并写入文件的间隔
+const fileQuotaFlushInterval = time.Second
+
+// FileQuotaStore 基于本地 JSON 文件的配额存储，仅保留当日数据
+// 计数保存在内存中，每秒最多写入一次文件，写入时先写临时文件再重命名，避免崩溃时文件损坏；
+// 进程退出前应调用 Flush，否则可能丢失最近一秒内的计数
+type FileQuotaStore struct {
+	mu        sync.Mutex
+	path      string
+	loaded    bool
+	data      fileQuota
+	dirty     bool
+	scheduled bool
+
+	writeMu sync.Mutex // 保证文件按顺序写入
+}
+
+// NewFileQuotaStore 创建文件配额存储
+func NewFileQuotaStore(path string) *FileQuotaStore {
+	return &FileQuotaStore{path: path}
+}
+
+// fileQuota 配额文件内容
+type fileQuota struct {
+	Day    string           `json:"day"`
+	Counts map[string]int64 `json:"counts"`
+}
+
+// Load 读取某天某接口的已用次数，文件只在首次调用时读取
+// 文件损坏时返回错误，之后视为空文件，下次写入时覆盖
+func (s *FileQuotaStore) Load(day, endpoint string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := s.load()
+	if s.data.Day != day {
+		return 0, err
+	}
+	return s.data.Counts[endpoint], err
+}
+
+// Save 保存某天某接口的已用次数，只更新内存，稍后合并写入文件
+func (s *FileQuotaStore) Save(day, endpoint string, count int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.load()
+	if s.data.Day != day {
+		s.data = fileQuota{Day: day, Counts: make(map[string]int64)}
+	}
+	s.data.Counts[endpoint] = count
+	s.dirty = true
+	if !s.scheduled {
+		s.scheduled = true
+		time.AfterFunc(fileQuotaFlushInterval, func() {
+			s.mu.Lock()
+			s.scheduled = false
+			s.mu.Unlock()
+			_ = s.Flush()
+		})
+	}
+	return nil
+}
+
+// Flush 将内存中的计数立即写入文件
+func (s *FileQuotaStore) Flush() error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.mu.Lock()
+	if !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	b, err := json.Marshal(s.data)
+	s.dirty = false
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(s.path, b, 0o600); err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// load 首次使用时读取文件，文件不存在或损坏时从空数据开始
+func (s *FileQuotaStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	s.loaded = true
+	s.data = fileQuota{Counts: make(map[string]int64)}
+
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var data fileQuota
+	if err := json.Unmarshal(b, &data); err != nil {
+		return fmt.Errorf("parse %s err: %w", s.path, err)
+	}
+	if data.Counts == nil {
+		data.Counts = make(map[string]int64)
+	}
+	s.data = data
+	return nil
+}
+
+// writeFileAtomic 先写入同目录下的临时文件再重命名，保证文件内容完整
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	defer os.Remove(tmp)
+
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(perm); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,232 @@
+package amap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBlocking(t *testing.T) {
+	limiter := NewRateLimiter(10)
+	ctx := context.Background()
+
+	start := time.Now()
+	// 桶容量为 10，第 11 个请求需要等待约 100ms
+	for range 11 {
+		if err := limiter.Wait(ctx, "ip"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Fatalf("期望等待约 100ms，实际 %v", elapsed)
+	}
+}
+
+func TestRateLimiterFailFast(t *testing.T) {
+	limiter := NewRateLimiter(0).SetEndpointQPS("geocode/geo", 2).SetFailFast(true)
+	ctx := context.Background()
+
+	for range 2 {
+		if err := limiter.Wait(ctx, "geocode/geo"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := limiter.Wait(ctx, "geocode/geo"); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("期望 ErrRateLimited，实际为: %v", err)
+	}
+	// 未配置 QPS 的接口不限流
+	for range 100 {
+		if err := limiter.Wait(ctx, "ip"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestRateLimiterDailyQuotaReset(t *testing.T) {
+	now := time.Date(2025, 1, 1, 23, 59, 0, 0, shanghai)
+	limiter := NewRateLimiter(0).SetDailyQuota("ip", 2)
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for range 2 {
+		if err := limiter.Wait(ctx, "ip"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := limiter.Wait(ctx, "ip"); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("期望 ErrDailyQuotaExceeded，实际为: %v", err)
+	}
+
+	// 北京时间零点后重置，UTC 时间仍是前一天
+	now = time.Date(2025, 1, 1, 16, 1, 0, 0, time.UTC)
+	if err := limiter.Wait(ctx, "ip"); err != nil {
+		t.Fatalf("期望零点后配额重置，实际为: %v", err)
+	}
+	if n := limiter.DailyUsage("ip"); n != 1 {
+		t.Fatalf("期望已用 1 次，实际 %d 次", n)
+	}
+}
+
+func TestRateLimiterQuotaStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	store := NewFileQuotaStore(path)
+	ctx := context.Background()
+
+	limiter := NewRateLimiter(0).SetDailyQuota("", 3).SetQuotaStore(store)
+	for range 2 {
+		if err := limiter.Wait(ctx, "ip"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(path); err != nil || fi.Mode().Perm() != 0o600 {
+		t.Fatalf("配额文件权限错误: %v %v", fi, err)
+	}
+
+	// 新的限流器从文件中恢复计数
+	limiter = NewRateLimiter(0).SetDailyQuota("", 3).SetQuotaStore(NewFileQuotaStore(path))
+	if err := limiter.Wait(ctx, "geocode/geo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.Wait(ctx, "geocode/regeo"); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("期望 ErrDailyQuotaExceeded，实际为: %v", err)
+	}
+}
+
+func TestRateLimiterExhaustedByServer(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"status":"0","info":"DAILY_QUERY_OVER_LIMIT","infocode":"10003"}`))
+	})
+	client.SetRateLimiter(NewRateLimiter(0).SetDailyQuota("ip", 1000))
+
+	for range 3 {
+		_, _ = client.IP(&IPRequest{IP: "114.247.50.2"})
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("期望配额用尽后不再请求，实际请求 %d 次", n)
+	}
+}
+
+func TestRateLimiterExhaustOnlyEndpoint(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/geocode/geo" {
+			_, _ = w.Write([]byte(`{"status":"0","info":"DAILY_QUERY_OVER_LIMIT","infocode":"10003"}`))
+			return
+		}
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	limiter := NewRateLimiter(0).SetDailyQuota("", 1000)
+	client.SetRateLimiter(limiter)
+
+	if _, err := client.Geocode(&GeocodeRequest{Address: "北京市朝阳区阜通东大街6号"}); !IsQuotaExceeded(err) {
+		t.Fatalf("期望配额超限错误，实际为: %v", err)
+	}
+	if _, err := client.Geocode(&GeocodeRequest{Address: "北京市朝阳区阜通东大街6号"}); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("期望该接口在本地被拦截，实际为: %v", err)
+	}
+	// 其他接口与合计配额不受影响
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatalf("其他接口不应被拦截，实际为: %v", err)
+	}
+	if n := limiter.DailyUsage(""); n != 2 {
+		t.Errorf("合计配额不应被填满，实际已用 %d", n)
+	}
+}
+
+func TestRateLimiterZeroValue(t *testing.T) {
+	var limiter RateLimiter
+	if err := limiter.Wait(context.Background(), "ip"); err != nil {
+		t.Fatalf("零值限流器不应限制请求: %v", err)
+	}
+
+	limiter.SetEndpointQPS("ip", 100).SetDailyQuota("ip", 1)
+	if err := limiter.Wait(context.Background(), "ip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := limiter.Wait(context.Background(), "ip"); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("期望 ErrDailyQuotaExceeded，实际为: %v", err)
+	}
+	limiter.exhaust("", "geocode/geo")
+	if n := limiter.DailyUsage("ip"); n != 1 {
+		t.Errorf("当日用量错误: %d", n)
+	}
+}
+
+func TestRateLimiterPerKey(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	limiter := NewRateLimiter(0).SetEndpointQPS("ip", 1).SetFailFast(true).SetDailyQuota("ip", 2)
+	client.SetRateLimiter(limiter)
+	client.SetKeyPool(NewKeyPool("a", "b"))
+
+	// 每个 Key 各有 1 QPS，两个 Key 可以同时请求
+	for range 2 {
+		if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+			t.Fatalf("不同 Key 不应共用令牌桶: %v", err)
+		}
+	}
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("期望 ErrRateLimited，实际为: %v", err)
+	}
+	if a, b := limiter.KeyDailyUsage("a", "ip"), limiter.KeyDailyUsage("b", "ip"); a != 1 || b != 1 {
+		t.Errorf("每个 Key 应分别计数，实际 a=%d b=%d", a, b)
+	}
+
+	// Key a 的日配额用尽后切换到 Key b
+	limiter.SetEndpointQPS("ip", 0)
+	limiter.exhaust("a", "ip")
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatalf("期望切换到其他 Key，实际错误: %v", err)
+	}
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); !errors.Is(err, ErrDailyQuotaExceeded) {
+		t.Fatalf("所有 Key 配额用尽时期望 ErrDailyQuotaExceeded，实际为: %v", err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Errorf("期望请求 3 次，实际 %d 次", n)
+	}
+}
+
+func TestFileQuotaStoreCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "quota.json")
+	if err := os.WriteFile(path, []byte(`{"day":"2026-10-1`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	store := NewFileQuotaStore(path)
+	limiter := NewRateLimiter(0).SetDailyQuota("ip", 10).SetQuotaStore(store)
+	client.SetRateLimiter(limiter)
+	client.SetKeyPool(NewKeyPool("raw-secret-key"))
+
+	// 文件损坏时从 0 开始计数，不影响请求
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatalf("配额文件损坏不应导致请求失败: %v", err)
+	}
+	if n := limiter.KeyDailyUsage("raw-secret-key", "ip"); n != 1 {
+		t.Errorf("当日用量错误: %d", n)
+	}
+	if err := store.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "raw-secret-key") {
+		t.Errorf("配额文件不应包含原始 Key: %s", b)
+	}
+}
@@ -0,0 +1,123 @@
+package amap
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"time"
+)
+
+// Attempt 单次请求的结果，用于判断是否需要重试
+type Attempt struct {
+	Number     int    // 第几次尝试，从 1 开始
+	StatusCode int    // HTTP 状态码，网络错误时为 0
+	InfoCode   string // 高德返回的 infocode，未解析到时为空
+	Err        error  // 本次尝试的错误
+}
+
+// RetryClassifier 判断本次尝试是否可以重试
+type RetryClassifier func(a Attempt) bool
+
+// DefaultRetryClassifier 默认的重试判断
+// 网络错误、5xx、429 以及 QPS 超限、服务繁忙类 infocode 会重试，Key 或参数错误不重试
+func DefaultRetryClassifier(a Attempt) bool {
+	if a.Err == nil {
+		return false
+	}
+	if a.InfoCode != "" {
+		c, ok := lookupInfoCode(a.InfoCode)
+		return ok && c.retryable
+	}
+	if a.StatusCode == http.StatusTooManyRequests || a.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	// 未收到响应，视为网络错误
+	return a.StatusCode == 0
+}
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	MaxAttempts       int             // 最大尝试次数（含首次），小于等于 1 表示不重试
+	InitialBackoff    time.Duration   // 首次重试前的等待时间
+	MaxBackoff        time.Duration   // 最大等待时间，0 表示不限制
+	Multiplier        float64         // 退避倍数，小于 1 时按 2 处理
+	Jitter            float64         // 抖动比例，取值 [0,1]，等待时间在 ±Jitter 范围内随机
+	PerAttemptTimeout time.Duration   // 单次尝试超时，0 表示不限制
+	Classifier        RetryClassifier // 重试判断，为空时使用 DefaultRetryClassifier
+}
+
+// DefaultRetryPolicy 默认重试策略，最多尝试 3 次
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// maxAttempts 最大尝试次数
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// attemptTimeout 单次尝试超时
+func (p *RetryPolicy) attemptTimeout() time.Duration {
+	if p == nil {
+		return 0
+	}
+	return p.PerAttemptTimeout
+}
+
+// shouldRetry 判断是否需要发起下一次尝试
+func (p *RetryPolicy) shouldRetry(ctx context.Context, a Attempt) bool {
+	if a.Number >= p.maxAttempts() || ctx.Err() != nil {
+		return false
+	}
+	// 调用方主动取消的请求不重试
+	if errors.Is(a.Err, context.Canceled) {
+		return false
+	}
+	classifier := p.Classifier
+	if classifier == nil {
+		classifier = DefaultRetryClassifier
+	}
+	return classifier(a)
+}
+
+// backoff 计算第 n 次尝试失败后的等待时间
+func (p *RetryPolicy) backoff(n int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	d := float64(p.InitialBackoff) * math.Pow(multiplier, float64(n-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if jitter := min(max(p.Jitter, 0), 1); jitter > 0 {
+		d += d * jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(d)
+}
+
+// sleepContext 等待指定时间，context 取消时提前返回
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
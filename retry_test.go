@@ -0,0 +1,126 @@
+package amap
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const okIPResponse = `{"status":"1","info":"OK","infocode":"10000","province":"北京市","city":"北京市","adcode":"110000"}`
+
+// fastRetryPolicy 测试用的重试策略，不等待
+func fastRetryPolicy(attempts int) *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: attempts, InitialBackoff: time.Millisecond}
+}
+
+func TestRetryQPSExceeded(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			_, _ = w.Write([]byte(`{"status":"0","info":"CUQPS_HAS_EXCEEDED_THE_LIMIT","infocode":"10019"}`))
+			return
+		}
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	client.SetRetryPolicy(fastRetryPolicy(3))
+
+	resp, err := client.IP(&IPRequest{IP: "114.247.50.2"})
+	if err != nil {
+		t.Fatalf("期望重试后成功，实际错误: %v", err)
+	}
+	if resp.Province != "北京市" {
+		t.Fatalf("省份错误: %s", resp.Province)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("期望请求 3 次，实际 %d 次", n)
+	}
+}
+
+func TestRetryInvalidKeyNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"status":"0","info":"INVALID_USER_KEY","infocode":"10001"}`))
+	})
+	client.SetRetryPolicy(fastRetryPolicy(3))
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("期望请求 1 次，实际 %d 次", n)
+	}
+}
+
+func TestRetryServerError(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.SetRetryPolicy(fastRetryPolicy(4))
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if n := calls.Load(); n != 4 {
+		t.Fatalf("期望请求 4 次，实际 %d 次", n)
+	}
+}
+
+func TestRetryPerAttemptTimeout(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	policy := fastRetryPolicy(2)
+	policy.PerAttemptTimeout = 50 * time.Millisecond
+	client.SetRetryPolicy(policy)
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatalf("期望重试后成功，实际错误: %v", err)
+	}
+}
+
+func TestRetryCustomClassifier(t *testing.T) {
+	var calls atomic.Int32
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"status":"0","info":"INVALID_PARAMS","infocode":"20000"}`))
+	})
+	policy := fastRetryPolicy(2)
+	policy.Classifier = func(a Attempt) bool { return a.InfoCode == "20000" }
+	client.SetRetryPolicy(policy)
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err == nil {
+		t.Fatal("期望返回错误")
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("期望请求 2 次，实际 %d 次", n)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("第 %d 次等待时间期望 %v，实际 %v", i+1, w, got)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if got := policy.backoff(1); got < 50*time.Millisecond || got > 150*time.Millisecond {
+			t.Fatalf("抖动超出范围: %v", got)
+		}
+	}
+}
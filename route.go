@@ -0,0 +1,320 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RouteResponse 步行、骑行、电动车路径规划响应
+type RouteResponse struct {
+	BaseResponse
+	Count string // 路径规划方案总数
+	Route Route  // 路径规划方案
+}
+
+// UnmarshalJSON 兼容 v3/v5 的 route 与 v4 的 data 两种响应格式
+func (r *RouteResponse) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		BaseResponse
+		errcodeEnvelope
+		Count string `json:"count"`
+		Route *Route `json:"route"`
+		Data  *Route `json:"data"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*r = RouteResponse{BaseResponse: aux.BaseResponse, Count: aux.Count}
+	if aux.Status == "" && aux.ErrCode != nil {
+		r.BaseResponse = aux.errcodeEnvelope.base()
+	}
+	switch {
+	case aux.Route != nil:
+		r.Route = *aux.Route
+	case aux.Data != nil:
+		r.Route = *aux.Data
+	}
+	return nil
+}
+
+// Route 路径规划方案
+// 驾车、货车、步行、骑行等路径规划共用，v3 与 v5 接口的响应都会解析到该结构
+type Route struct {
+	Origin      string  // 起点坐标
+	Destination string  // 终点坐标
+	TaxiCost    float64 // 预计出租车费用，单位：元，仅驾车返回
+	Paths       []Path  // 路线方案
+}
+
+// UnmarshalJSON 解析数值字段
+func (r *Route) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Origin      string    `json:"origin"`
+		Destination string    `json:"destination"`
+		TaxiCost    numString `json:"taxi_cost"`
+		Paths       []Path    `json:"paths"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*r = Route{
+		Origin:      aux.Origin,
+		Destination: aux.Destination,
+		TaxiCost:    aux.TaxiCost.Float(),
+		Paths:       aux.Paths,
+	}
+	return nil
+}
+
+// Path 路线方案
+type Path struct {
+	Distance      int     // 距离，单位：米
+	Duration      int     // 预计耗时，单位：秒
+	Strategy      string  // 导航策略
+	Tolls         float64 // 道路收费，单位：元
+	TollDistance  int     // 收费路段距离，单位：米
+	Restriction   int     // 限行结果：0 已规避或未限行，1 限行无法规避
+	TrafficLights int     // 红绿灯个数
+	Steps         []Step  // 导航路段
+}
+
+// UnmarshalJSON 解析数值字段，兼容 v5 接口将耗时与费用放在 cost 中
+func (p *Path) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Distance      numString  `json:"distance"`
+		Duration      numString  `json:"duration"`
+		Strategy      string     `json:"strategy"`
+		Tolls         numString  `json:"tolls"`
+		TollDistance  numString  `json:"toll_distance"`
+		Restriction   numString  `json:"restriction"`
+		TrafficLights numString  `json:"traffic_lights"`
+		Cost          *routeCost `json:"cost"`
+		Steps         []Step     `json:"steps"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	if aux.Cost != nil {
+		aux.Cost.fill(&aux.Duration, &aux.Tolls, &aux.TollDistance, &aux.TrafficLights)
+	}
+	*p = Path{
+		Distance:      aux.Distance.Int(),
+		Duration:      aux.Duration.Int(),
+		Strategy:      aux.Strategy,
+		Tolls:         aux.Tolls.Float(),
+		TollDistance:  aux.TollDistance.Int(),
+		Restriction:   aux.Restriction.Int(),
+		TrafficLights: aux.TrafficLights.Int(),
+		Steps:         aux.Steps,
+	}
+	return nil
+}
+
+// Step 导航路段
+type Step struct {
+	Instruction     string       // 行驶指示
+	Orientation     string       // 方向
+	Road            string       // 道路名称
+	Distance        int          // 此路段距离，单位：米
+	Duration        int          // 此路段预计耗时，单位：秒
+	Tolls           float64      // 此路段收费，单位：元
+	TollDistance    int          // 收费路段距离，单位：米
+	TollRoad        string       // 主要收费道路
+	Action          string       // 导航主要动作
+	AssistantAction string       // 导航辅助动作
+	Polyline        []Coordinate // 此路段坐标点
+}
+
+// UnmarshalJSON 解析数值字段与坐标串，兼容 v5 接口的字段名
+func (s *Step) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Instruction     string     `json:"instruction"`
+		Orientation     string     `json:"orientation"`
+		Road            string     `json:"road"`
+		RoadName        string     `json:"road_name"`
+		Distance        numString  `json:"distance"`
+		StepDistance    numString  `json:"step_distance"`
+		Duration        numString  `json:"duration"`
+		Tolls           numString  `json:"tolls"`
+		TollDistance    numString  `json:"toll_distance"`
+		TollRoad        string     `json:"toll_road"`
+		Action          string     `json:"action"`
+		AssistantAction string     `json:"assistant_action"`
+		Polyline        string     `json:"polyline"`
+		Cost            *routeCost `json:"cost"`
+		Navi            *struct {
+			Action          string `json:"action"`
+			AssistantAction string `json:"assistant_action"`
+		} `json:"navi"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	if aux.Cost != nil {
+		aux.Cost.fill(&aux.Duration, &aux.Tolls, &aux.TollDistance, nil)
+		aux.TollRoad = firstNonEmpty(aux.TollRoad, aux.Cost.TollRoad)
+	}
+	if aux.Distance == "" {
+		aux.Distance = aux.StepDistance
+	}
+	if aux.Navi != nil {
+		aux.Action = firstNonEmpty(aux.Action, aux.Navi.Action)
+		aux.AssistantAction = firstNonEmpty(aux.AssistantAction, aux.Navi.AssistantAction)
+	}
+	polyline, err := ParsePolyline(aux.Polyline)
+	if err != nil {
+		return err
+	}
+	*s = Step{
+		Instruction:     aux.Instruction,
+		Orientation:     aux.Orientation,
+		Road:            firstNonEmpty(aux.Road, aux.RoadName),
+		Distance:        aux.Distance.Int(),
+		Duration:        aux.Duration.Int(),
+		Tolls:           aux.Tolls.Float(),
+		TollDistance:    aux.TollDistance.Int(),
+		TollRoad:        aux.TollRoad,
+		Action:          aux.Action,
+		AssistantAction: aux.AssistantAction,
+		Polyline:        polyline,
+	}
+	return nil
+}
+
+// Polyline 返回方案的完整坐标串，由各路段坐标依次拼接
+func (p *Path) Polyline() []Coordinate {
+	var coords []Coordinate
+	for _, step := range p.Steps {
+		for _, c := range step.Polyline {
+			// 相邻路段首尾坐标相同，去除重复点
+			if n := len(coords); n > 0 && coords[n-1] == c {
+				continue
+			}
+			coords = append(coords, c)
+		}
+	}
+	return coords
+}
+
+// routeCost v5 接口的耗时与费用信息
+type routeCost struct {
+	Duration      numString `json:"duration"`
+	Tolls         numString `json:"tolls"`
+	TollDistance  numString `json:"toll_distance"`
+	TollRoad      string    `json:"toll_road"`
+	TrafficLights numString `json:"traffic_lights"`
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+func (c *routeCost) UnmarshalJSON(data []byte) error {
+	type alias routeCost
+	return unmarshalLenient(data, (*alias)(c))
+}
+
+// fill 将 cost 中的非空字段填充到对应位置，传入 nil 表示忽略该字段
+func (c *routeCost) fill(duration, tolls, tollDistance, trafficLights *numString) {
+	for _, f := range []struct {
+		dst *numString
+		src numString
+	}{
+		{duration, c.Duration},
+		{tolls, c.Tolls},
+		{tollDistance, c.TollDistance},
+		{trafficLights, c.TrafficLights},
+	} {
+		if f.dst != nil && *f.dst == "" {
+			*f.dst = f.src
+		}
+	}
+}
+
+// checkOriginDestination 校验路径规划的起终点
+func checkOriginDestination(origin, destination string) error {
+	if origin == "" || destination == "" {
+		return fmt.Errorf("%w: origin 与 destination 不能为空", ErrInvalidParams)
+	}
+	return nil
+}
+
+// setAvoidance 设置驾车与货车的途经点和避让区域，多边形之间以 "|" 分隔
+func setAvoidance(params url.Values, waypoints []string, polygons [][]Coordinate) {
+	if len(waypoints) > 0 {
+		params.Set("waypoints", strings.Join(waypoints, ";"))
+	}
+
+	if len(polygons) > 0 {
+		joined := make([]string, len(polygons))
+		for i, p := range polygons {
+			joined[i] = joinCoordinates(p, ";")
+		}
+		params.Set("avoidpolygons", strings.Join(joined, "|"))
+	}
+}
+
+// doRouteRequest 执行步行、骑行、电动车路径规划请求
+func (c *Client) doRouteRequest(ctx context.Context, endpoint string, params url.Values, cacheKeyParams interface{}) (*RouteResponse, error) {
+	// 使用带缓存的请求
+	body, err := c.doRequestWithCache(ctx, endpoint, params, cacheKeyParams)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp RouteResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// numString 数值字段，高德 v3/v5 接口以字符串返回，v4 接口以数字返回
+type numString string
+
+// UnmarshalJSON 同时接受字符串与数字，空数组视为空字符串
+func (s *numString) UnmarshalJSON(data []byte) error {
+	var v string
+	switch {
+	case len(data) == 0, data[0] == '[':
+		*s = ""
+		return nil
+	case data[0] != '"' && data[0] != 'n':
+		*s = numString(data)
+		return nil
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = numString(v)
+	return nil
+}
+
+// Int 解析为整数，兼容 "123.0" 形式的小数，解析失败时返回 0
+func (s numString) Int() int {
+	if n, err := strconv.Atoi(string(s)); err == nil {
+		return n
+	}
+	return int(s.Float())
+}
+
+// Float 解析为浮点数，解析失败时返回 0
+func (s numString) Float() float64 {
+	return parseFloat(string(s))
+}
+
+// firstNonEmpty 返回第一个非空字符串
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
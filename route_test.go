@@ -0,0 +1,123 @@
+package amap
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const (
+	walkingJSON     = `{"status":"1","info":"ok","infocode":"10000","count":"1","route":{"origin":"116.434307,39.90909","destination":"116.434446,39.90816","paths":[{"distance":"114","duration":"91","steps":[{"instruction":"向南步行114米到达目的地","orientation":"南","road":[],"distance":"114","duration":"91","polyline":"116.434307,39.90909;116.434446,39.90816","action":[],"assistant_action":"到达目的地","walk_type":"0"}]}]}}`
+	bicyclingJSON   = `{"data":{"origin":"116.434307,39.90909","destination":"116.434446,39.90816","paths":[{"distance":120,"duration":29,"steps":[{"instruction":"骑行120米到达终点","road":"东单北大街","distance":120,"orientation":"南","duration":29,"polyline":"116.434307,39.90909;116.434446,39.90816","action":"","assistant_action":"到达目的地"}]}]},"errcode":0,"errdetail":null,"errmsg":"OK"}`
+	electroBikeJSON = `{"status":"1","info":"OK","infocode":"10000","count":"1","route":{"origin":"116.434307,39.90909","destination":"116.434446,39.90816","paths":[{"distance":"130","duration":"35","steps":[{"instruction":"骑行130米到达终点","orientation":"南","road_name":"东单北大街","step_distance":"130","cost":{"duration":"35"},"polyline":"116.434307,39.90909;116.434446,39.90816"}]}]}}`
+)
+
+func TestRouteJSONParsing(t *testing.T) {
+	for name, data := range map[string]string{
+		"walking":     walkingJSON,
+		"bicycling":   bicyclingJSON,
+		"electrobike": electroBikeJSON,
+	} {
+		var resp RouteResponse
+		if err := json.Unmarshal([]byte(data), &resp); err != nil {
+			t.Fatalf("%s JSON解析失败: %v", name, err)
+		}
+		if err := resp.GetError(); err != nil {
+			t.Errorf("%s 响应状态错误: %v", name, err)
+		}
+		if resp.Route.Destination != "116.434446,39.90816" || len(resp.Route.Paths) != 1 {
+			t.Fatalf("%s 路线解析错误: %+v", name, resp.Route)
+		}
+		path := resp.Route.Paths[0]
+		if path.Distance == 0 || path.Duration == 0 || len(path.Steps) != 1 {
+			t.Errorf("%s 方案解析错误: %+v", name, path)
+		}
+		if step := path.Steps[0]; step.Distance != path.Distance || step.Duration != path.Duration || len(step.Polyline) != 2 {
+			t.Errorf("%s 路段解析错误: %+v", name, step)
+		}
+	}
+}
+
+func TestDecodeBase(t *testing.T) {
+	tests := []struct {
+		data string
+		want BaseResponse
+	}{
+		{`{"status":"1","info":"OK","infocode":"10000"}`, BaseResponse{Status: "1", Info: "OK", InfoCode: "10000"}},
+		{`{"data":{},"errcode":0,"errmsg":"OK"}`, BaseResponse{Status: "1", Info: "OK", InfoCode: "10000"}},
+		{`{"errcode":10001,"errmsg":"INVALID_USER_KEY"}`, BaseResponse{Status: "0", Info: "INVALID_USER_KEY", InfoCode: "10001"}},
+		{`{"errcode":"10003","errmsg":"DAILY_QUERY_OVER_LIMIT"}`, BaseResponse{Status: "0", Info: "DAILY_QUERY_OVER_LIMIT", InfoCode: "10003"}},
+	}
+	for _, tt := range tests {
+		got, err := decodeBase([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("%s 解析失败: %v", tt.data, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestRouteRequests(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("origin") == "" || q.Get("destination") == "" {
+			t.Errorf("缺少起终点: %s", r.URL.RawQuery)
+		}
+		switch r.URL.Path {
+		case "/v3/direction/walking":
+			_, _ = w.Write([]byte(walkingJSON))
+		case "/v5/direction/walking":
+			if q.Get("alternative_route") != "3" || q.Has("show_fields") {
+				t.Errorf("步行 v5 请求参数错误: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(electroBikeJSON))
+		case "/v4/direction/bicycling":
+			if q.Get("origin") == "0,0" {
+				_, _ = w.Write([]byte(`{"data":[],"errcode":10001,"errdetail":null,"errmsg":"INVALID_USER_KEY"}`))
+				return
+			}
+			_, _ = w.Write([]byte(bicyclingJSON))
+		case "/v5/direction/electrobike":
+			if q.Get("alternative_route") != "2" || q.Get("show_fields") != "cost,polyline" {
+				t.Errorf("电动车请求参数错误: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(electroBikeJSON))
+		default:
+			t.Errorf("未知接口: %s", r.URL.Path)
+		}
+	})
+
+	origin, destination := "116.434307,39.90909", "116.434446,39.90816"
+	if _, err := client.Walking(&WalkingRequest{Origin: origin, Destination: destination}); err != nil {
+		t.Errorf("步行路径规划失败: %v", err)
+	}
+	// 只设置 AlternativeRoute 时也使用 v5 接口
+	if _, err := client.Walking(&WalkingRequest{Origin: origin, Destination: destination, AlternativeRoute: 3}); err != nil {
+		t.Errorf("步行多方案路径规划失败: %v", err)
+	}
+	resp, err := client.Bicycling(&BicyclingRequest{Origin: origin, Destination: destination})
+	if err != nil {
+		t.Fatalf("骑行路径规划失败: %v", err)
+	}
+	if resp.Route.Paths[0].Steps[0].Road != "东单北大街" {
+		t.Errorf("骑行响应解析错误: %+v", resp.Route)
+	}
+	if _, err := client.ElectroBike(&ElectroBikeRequest{
+		Origin:           origin,
+		Destination:      destination,
+		AlternativeRoute: 2,
+		ShowFields:       []string{ShowFieldCost, ShowFieldPolyline},
+	}); err != nil {
+		t.Errorf("电动车路径规划失败: %v", err)
+	}
+
+	_, err = client.Bicycling(&BicyclingRequest{Origin: "0,0", Destination: destination})
+	if !IsInvalidKey(err) {
+		t.Errorf("errcode 应转换为 APIError，实际: %v", err)
+	}
+	if _, err := client.Walking(&WalkingRequest{Destination: destination}); !IsInvalidParams(err) {
+		t.Errorf("缺少起点应返回参数错误，实际: %v", err)
+	}
+}
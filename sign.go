@@ -0,0 +1,37 @@
+package amap
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// sign 计算高德数字签名
+// 参数按名称升序排列后以 k=v&k=v 拼接（值不做 URL 编码），末尾追加私钥，取 MD5
+// https://lbs.amap.com/faq/quota-key/key/41181
+func sign(params url.Values, secret string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k == "sig" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(params.Get(k))
+	}
+	b.WriteString(secret)
+
+	hash := md5.Sum([]byte(b.String()))
+	return hex.EncodeToString(hash[:])
+}
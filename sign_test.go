@@ -0,0 +1,49 @@
+package amap
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		params url.Values
+		secret string
+		want   string
+	}{
+		{
+			params: url.Values{"address": {"北京市朝阳区望京SOHO"}, "output": {"xml"}, "key": {"aaaaaaaaaaa"}},
+			secret: "bbbbbbbbbbb",
+			want:   "cbd9c8f2a1f827336791a6513ad5e979",
+		},
+		{
+			params: url.Values{"key": {"test-key"}, "ip": {"114.247.50.2"}, "sig": {"ignored"}},
+			secret: "secret",
+			want:   "8f21a4dec6de0e612e6a6259c80e2483",
+		},
+	}
+	for _, tt := range tests {
+		if got := sign(tt.params, tt.secret); got != tt.want {
+			t.Errorf("签名错误，期望 %s，实际 %s", tt.want, got)
+		}
+	}
+}
+
+func TestRequestSigned(t *testing.T) {
+	var sig string
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		sig = r.URL.Query().Get("sig")
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	client.SetSecret("secret")
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	if _, err := client.IP(&IPRequest{IP: "114.247.50.2"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "8f21a4dec6de0e612e6a6259c80e2483"; sig != want {
+		t.Fatalf("签名错误，期望 %s，实际 %s", want, sig)
+	}
+}
@@ -0,0 +1,161 @@
+package amap
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Tracer 链路追踪接口，可实现适配器对接 OpenTelemetry 等追踪系统
+type Tracer interface {
+	// Start 创建 span，返回的 context 携带该 span，后续的子 span 与 HTTP 请求都会使用它
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span 追踪片段
+type Span interface {
+	// SetAttribute 设置属性，value 为 string、int、bool 等基础类型
+	SetAttribute(key string, value any)
+	// RecordError 记录错误
+	RecordError(err error)
+	// End 结束 span
+	End()
+}
+
+// Propagator 可选接口，Tracer 实现该接口时，会将追踪上下文注入到发往高德的 HTTP 请求头中
+type Propagator interface {
+	Inject(ctx context.Context, header http.Header)
+}
+
+// nopTracer 不做任何记录的 Tracer
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string) (context.Context, Span) { return ctx, nopSpan{} }
+
+// nopSpan 不做任何记录的 Span
+type nopSpan struct{}
+
+func (nopSpan) SetAttribute(string, any) {}
+func (nopSpan) RecordError(error)        {}
+func (nopSpan) End()                     {}
+
+// tracer 返回追踪器，未设置时不记录
+func (c *Client) tracer() Tracer {
+	if c.Tracer == nil {
+		return nopTracer{}
+	}
+	return c.Tracer
+}
+
+// endCallSpan 记录调用结果并结束 span
+func endCallSpan(span Span, call *callInfo, err error) {
+	span.SetAttribute("amap.endpoint", call.Endpoint)
+	span.SetAttribute("amap.cache_hit", call.CacheHit)
+	if !call.CacheHit {
+		span.SetAttribute("amap.attempts", call.Attempt.Number)
+		span.SetAttribute("amap.infocode", call.Attempt.InfoCode)
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// RecordedSpan RecordingTracer 记录的 span
+type RecordedSpan struct {
+	Name       string
+	Parent     *RecordedSpan // 父 span，根 span 为 nil
+	Attributes map[string]any
+	Err        error
+	Start      time.Time
+	End        time.Time
+}
+
+// RecordingTracer 在内存中记录 span 的 Tracer，用于测试
+type RecordingTracer struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// NewRecordingTracer 创建内存追踪器
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{}
+}
+
+// recordedSpanKey context 中保存当前 span 的键
+type recordedSpanKey struct{}
+
+// Start 实现 Tracer
+func (t *RecordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(recordedSpanKey{}).(*RecordedSpan)
+	s := &RecordedSpan{
+		Name:       name,
+		Parent:     parent,
+		Attributes: make(map[string]any),
+		Start:      time.Now(),
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	t.mu.Unlock()
+	return context.WithValue(ctx, recordedSpanKey{}, s), &recordingSpan{tracer: t, span: s}
+}
+
+// Spans 返回已结束 span 的快照，按创建顺序排列
+// 快照中的 Attributes 与 Parent 均为副本，不受仍在进行中的 span 影响
+func (t *RecordingTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]RecordedSpan, 0, len(t.spans))
+	for _, s := range t.spans {
+		if !s.End.IsZero() {
+			out = append(out, s.snapshot())
+		}
+	}
+	return out
+}
+
+// snapshot 复制 span 及其父 span，调用方需持有 RecordingTracer 的锁
+func (s *RecordedSpan) snapshot() RecordedSpan {
+	out := *s
+	out.Attributes = maps.Clone(s.Attributes)
+	if s.Parent != nil {
+		parent := s.Parent.snapshot()
+		out.Parent = &parent
+	}
+	return out
+}
+
+// Reset 清空已记录的 span
+func (t *RecordingTracer) Reset() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spans = nil
+}
+
+// recordingSpan RecordingTracer 创建的 Span
+type recordingSpan struct {
+	tracer *RecordingTracer
+	span   *RecordedSpan
+}
+
+func (s *recordingSpan) SetAttribute(key string, value any) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.Attributes[key] = value
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.span.Err = err
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if s.span.End.IsZero() {
+		s.span.End = time.Now()
+	}
+}
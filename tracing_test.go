@@ -0,0 +1,131 @@
+package amap
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// headerTracer 测试用的 Tracer，实现 Propagator 注入请求头
+type headerTracer struct {
+	*RecordingTracer
+}
+
+func (headerTracer) Inject(_ context.Context, header http.Header) {
+	header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+}
+
+func TestTracing(t *testing.T) {
+	var traceparent string
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		_, _ = w.Write([]byte(okIPResponse))
+	})
+	tracer := headerTracer{NewRecordingTracer()}
+	client.SetTracer(tracer)
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	ctx, root := tracer.Start(context.Background(), "handler")
+	for range 2 {
+		if _, err := client.IPContext(ctx, &IPRequest{IP: "114.247.50.2"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	root.End()
+
+	if traceparent == "" {
+		t.Error("未注入追踪上下文")
+	}
+
+	spans := tracer.Spans()
+	if len(spans) != 4 {
+		t.Fatalf("期望 4 个 span，实际 %d 个", len(spans))
+	}
+	call, attempt, cached := spans[1], spans[2], spans[3]
+	if call.Name != "amap ip" || call.Parent == nil || call.Parent.Name != "handler" {
+		t.Errorf("调用 span 错误: %+v", call)
+	}
+	if call.Attributes["amap.cache_hit"] != false || call.Attributes["amap.attempts"] != 1 || call.Attributes["amap.infocode"] != "10000" {
+		t.Errorf("调用 span 属性错误: %v", call.Attributes)
+	}
+	if attempt.Parent == nil || attempt.Parent.Name != "amap ip" || attempt.Attributes["http.status_code"] != 200 {
+		t.Errorf("尝试 span 错误: %+v", attempt)
+	}
+	if cached.Attributes["amap.cache_hit"] != true {
+		t.Errorf("缓存命中 span 属性错误: %v", cached.Attributes)
+	}
+}
+
+func TestTracingError(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","info":"CUQPS_HAS_EXCEEDED_THE_LIMIT","infocode":"10021"}`))
+	})
+	tracer := NewRecordingTracer()
+	client.SetTracer(tracer)
+	client.SetRetryPolicy(fastRetryPolicy(2))
+
+	_, _ = client.IP(&IPRequest{IP: "114.247.50.2"})
+
+	spans := tracer.Spans()
+	if len(spans) != 3 {
+		t.Fatalf("期望 3 个 span，实际 %d 个", len(spans))
+	}
+	call := spans[0]
+	if call.Err == nil || call.Attributes["amap.attempts"] != 2 || call.Attributes["amap.infocode"] != "10021" {
+		t.Errorf("调用 span 错误: %+v", call)
+	}
+}
+
+func TestTracingNetworkErrorRedacted(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	// 指向已关闭的端口，触发连接失败
+	client.BaseURL = "http://127.0.0.1:1"
+	client.APIKey = "secretkey1234"
+	client.SetSecret("secret")
+	tracer := NewRecordingTracer()
+	client.SetTracer(tracer)
+
+	_, _ = client.IP(&IPRequest{IP: "114.247.50.2"})
+
+	spans := tracer.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("期望 2 个 span，实际 %d 个", len(spans))
+	}
+	for _, span := range spans {
+		if span.Err == nil {
+			t.Fatalf("%s span 未记录错误", span.Name)
+		}
+		msg := span.Err.Error()
+		if strings.Contains(msg, "secretkey1234") || !strings.Contains(msg, "key=***1234") || !strings.Contains(msg, "sig=***") {
+			t.Errorf("%s span 错误未脱敏: %s", span.Name, msg)
+		}
+		for k, v := range span.Attributes {
+			if s, ok := v.(string); ok && strings.Contains(s, "secretkey1234") {
+				t.Errorf("%s span 属性 %s 泄露了 API Key", span.Name, k)
+			}
+		}
+	}
+}
+
+func TestRecordingTracerSnapshot(t *testing.T) {
+	tracer := NewRecordingTracer()
+	ctx, parent := tracer.Start(context.Background(), "parent")
+	_, child := tracer.Start(ctx, "child")
+	child.SetAttribute("k", "v")
+	child.End()
+
+	spans := tracer.Spans()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// 父 span 仍在进行中，修改不应影响已返回的快照
+		parent.SetAttribute("k", "changed")
+		child.SetAttribute("k", "changed")
+	}()
+	if len(spans) != 1 || spans[0].Attributes["k"] != "v" || spans[0].Parent == nil || len(spans[0].Parent.Attributes) != 0 {
+		t.Errorf("快照错误: %+v", spans)
+	}
+	<-done
+}
@@ -0,0 +1,469 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 公交路径规划策略
+const (
+	TransitStrategyFastest     = 0 // 最快捷模式
+	TransitStrategyCheapest    = 1 // 最经济模式
+	TransitStrategyLeastChange = 2 // 最少换乘模式
+	TransitStrategyLeastWalk   = 3 // 最少步行模式
+	TransitStrategyNoSubway    = 5 // 不乘地铁模式
+)
+
+// TransitRequest 公交路径规划请求参数
+type TransitRequest struct {
+	Origin      string    // 起点坐标，必填 "经度,纬度"
+	Destination string    // 终点坐标，必填 "经度,纬度"
+	City        string    // 起点城市，必填，支持城市名称、citycode
+	CityD       string    // 终点城市，跨城时必填
+	Strategy    int       // 换乘策略，可选，见 TransitStrategy* 常量
+	NightFlag   bool      // 是否计算夜班车
+	Departure   time.Time // 出发时间，可选，零值表示当前时间
+	Extensions  string    // 返回结果控制：base(默认) 或 all
+}
+
+// TransitResponse 公交路径规划响应
+type TransitResponse struct {
+	BaseResponse
+	Count string       `json:"count"` // 公交换乘方案数目
+	Route TransitRoute `json:"route"` // 公交换乘信息
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况
+func (r *TransitResponse) UnmarshalJSON(data []byte) error {
+	type alias TransitResponse
+	return unmarshalLenient(data, (*alias)(r))
+}
+
+// TransitRoute 公交换乘信息
+type TransitRoute struct {
+	Origin      string    // 起点坐标
+	Destination string    // 终点坐标
+	Distance    int       // 起终点步行距离，单位：米
+	TaxiCost    float64   // 出租车费用，单位：元
+	Transits    []Transit // 换乘方案
+}
+
+// UnmarshalJSON 解析数值字段
+func (r *TransitRoute) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Origin      string    `json:"origin"`
+		Destination string    `json:"destination"`
+		Distance    numString `json:"distance"`
+		TaxiCost    numString `json:"taxi_cost"`
+		Transits    []Transit `json:"transits"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*r = TransitRoute{
+		Origin:      aux.Origin,
+		Destination: aux.Destination,
+		Distance:    aux.Distance.Int(),
+		TaxiCost:    aux.TaxiCost.Float(),
+		Transits:    aux.Transits,
+	}
+	return nil
+}
+
+// Transit 公交换乘方案
+type Transit struct {
+	Cost            float64   // 换乘方案价格，单位：元，部分方案不返回
+	Duration        int       // 预计耗时，单位：秒
+	NightFlag       bool      // 是否为夜班车
+	WalkingDistance int       // 步行总距离，单位：米
+	Distance        int       // 换乘方案总距离，单位：米
+	Missed          bool      // 是否可能错过末班车
+	Segments        []Segment // 换乘路段
+}
+
+// UnmarshalJSON 解析数值字段
+func (t *Transit) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Cost            numString `json:"cost"`
+		Duration        numString `json:"duration"`
+		NightFlag       numString `json:"nightflag"`
+		WalkingDistance numString `json:"walking_distance"`
+		Distance        numString `json:"distance"`
+		Missed          numString `json:"missed"`
+		Segments        []Segment `json:"segments"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*t = Transit{
+		Cost:            aux.Cost.Float(),
+		Duration:        aux.Duration.Int(),
+		NightFlag:       aux.NightFlag == "1",
+		WalkingDistance: aux.WalkingDistance.Int(),
+		Distance:        aux.Distance.Int(),
+		Missed:          aux.Missed == "1",
+		Segments:        aux.Segments,
+	}
+	return nil
+}
+
+// TotalFare 换乘方案总价，单位：元
+// 优先使用高德返回的方案价格，未返回时累加火车最低票价与出租车费用
+func (t *Transit) TotalFare() float64 {
+	if t.Cost > 0 {
+		return t.Cost
+	}
+	var fare float64
+	for _, seg := range t.Segments {
+		fare += seg.Railway.MinFare() + seg.Taxi.Price
+	}
+	return fare
+}
+
+// TotalWalkingDistance 各路段步行距离之和，单位：米
+func (t *Transit) TotalWalkingDistance() int {
+	var distance int
+	for _, seg := range t.Segments {
+		distance += seg.Walking.Distance
+	}
+	return distance
+}
+
+// Transfers 换乘次数，即乘坐公交、地铁、火车与出租车的次数减一
+func (t *Transit) Transfers() int {
+	var rides int
+	for _, seg := range t.Segments {
+		if len(seg.Bus.BusLines) > 0 {
+			rides++
+		}
+		if seg.Railway.ID != "" {
+			rides++
+		}
+		if seg.Taxi.Distance > 0 {
+			rides++
+		}
+	}
+	return max(rides-1, 0)
+}
+
+// Segment 换乘路段，每段依次为步行、公交/地铁、火车或出租车，未返回的部分为零值
+type Segment struct {
+	Walking  WalkingSegment `json:"walking"`  // 步行路段
+	Bus      BusSegment     `json:"bus"`      // 公交路段
+	Entrance Station        `json:"entrance"` // 地铁站入口
+	Exit     Station        `json:"exit"`     // 地铁站出口
+	Railway  Railway        `json:"railway"`  // 火车路段
+	Taxi     TaxiSegment    `json:"taxi"`     // 出租车路段
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况
+func (s *Segment) UnmarshalJSON(data []byte) error {
+	type alias Segment
+	return unmarshalLenient(data, (*alias)(s))
+}
+
+// WalkingSegment 步行路段
+type WalkingSegment struct {
+	Origin      string // 起点坐标
+	Destination string // 终点坐标
+	Distance    int    // 步行距离，单位：米
+	Duration    int    // 步行耗时，单位：秒
+	Steps       []Step // 步行导航路段
+}
+
+// UnmarshalJSON 解析数值字段
+func (w *WalkingSegment) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Origin      string    `json:"origin"`
+		Destination string    `json:"destination"`
+		Distance    numString `json:"distance"`
+		Duration    numString `json:"duration"`
+		Steps       []Step    `json:"steps"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*w = WalkingSegment{
+		Origin:      aux.Origin,
+		Destination: aux.Destination,
+		Distance:    aux.Distance.Int(),
+		Duration:    aux.Duration.Int(),
+		Steps:       aux.Steps,
+	}
+	return nil
+}
+
+// BusSegment 公交路段
+type BusSegment struct {
+	BusLines []BusLine `json:"buslines"` // 可选公交线路，第一条为推荐线路
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况
+func (b *BusSegment) UnmarshalJSON(data []byte) error {
+	type alias BusSegment
+	return unmarshalLenient(data, (*alias)(b))
+}
+
+// BusLine 公交、地铁线路
+type BusLine struct {
+	ID            string       // 线路ID
+	Name          string       // 线路名称
+	Type          string       // 线路类型，如 "普通公交线路"、"地铁线路"
+	Distance      int          // 乘坐距离，单位：米
+	Duration      int          // 乘坐耗时，单位：秒
+	StartTime     string       // 首班车时间，如 "0600"
+	EndTime       string       // 末班车时间，如 "2300"
+	ViaNum        int          // 途经站数，不含上下车站
+	DepartureStop Stop         // 上车站
+	ArrivalStop   Stop         // 下车站
+	ViaStops      []Stop       // 途经站
+	Polyline      []Coordinate // 线路坐标点
+}
+
+// UnmarshalJSON 解析数值字段与坐标串
+func (b *BusLine) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ID            string    `json:"id"`
+		Name          string    `json:"name"`
+		Type          string    `json:"type"`
+		Distance      numString `json:"distance"`
+		Duration      numString `json:"duration"`
+		StartTime     string    `json:"start_time"`
+		EndTime       string    `json:"end_time"`
+		ViaNum        numString `json:"via_num"`
+		DepartureStop Stop      `json:"departure_stop"`
+		ArrivalStop   Stop      `json:"arrival_stop"`
+		ViaStops      []Stop    `json:"via_stops"`
+		Polyline      string    `json:"polyline"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	polyline, err := ParsePolyline(aux.Polyline)
+	if err != nil {
+		return err
+	}
+	*b = BusLine{
+		ID:            aux.ID,
+		Name:          aux.Name,
+		Type:          aux.Type,
+		Distance:      aux.Distance.Int(),
+		Duration:      aux.Duration.Int(),
+		StartTime:     aux.StartTime,
+		EndTime:       aux.EndTime,
+		ViaNum:        aux.ViaNum.Int(),
+		DepartureStop: aux.DepartureStop,
+		ArrivalStop:   aux.ArrivalStop,
+		ViaStops:      aux.ViaStops,
+		Polyline:      polyline,
+	}
+	return nil
+}
+
+// Stop 公交站、地铁站或火车站
+type Stop struct {
+	ID       string `json:"id"`       // 站点ID
+	Name     string `json:"name"`     // 站点名称
+	Location string `json:"location"` // 站点坐标
+	AdCode   string `json:"adcode"`   // 所在区域编码，仅火车站返回
+	Time     string `json:"time"`     // 发车或到站时间，仅火车站返回，如 "1230"
+}
+
+// UnmarshalJSON 兼容高德空字段返回 [] 的情况
+func (s *Stop) UnmarshalJSON(data []byte) error {
+	type alias Stop
+	return unmarshalLenient(data, (*alias)(s))
+}
+
+// Station 地铁站出入口
+type Station struct {
+	Name     string `json:"name"`     // 出入口名称
+	Location string `json:"location"` // 出入口坐标
+}
+
+// UnmarshalJSON 兼容高德空对象返回 [] 的情况
+func (s *Station) UnmarshalJSON(data []byte) error {
+	type alias Station
+	return unmarshalLenient(data, (*alias)(s))
+}
+
+// Railway 火车路段
+type Railway struct {
+	ID            string         // 线路ID
+	Name          string         // 线路名称
+	Trip          string         // 车次
+	Type          string         // 列车类型
+	Time          int            // 乘坐耗时，单位：秒
+	Distance      int            // 乘坐距离，单位：米
+	DepartureStop Stop           // 上车站
+	ArrivalStop   Stop           // 下车站
+	ViaStops      []Stop         // 途经站
+	Spaces        []RailwaySpace // 席位与票价
+}
+
+// UnmarshalJSON 解析数值字段
+func (r *Railway) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		ID            string         `json:"id"`
+		Name          string         `json:"name"`
+		Trip          string         `json:"trip"`
+		Type          string         `json:"type"`
+		Time          numString      `json:"time"`
+		Distance      numString      `json:"distance"`
+		DepartureStop Stop           `json:"departure_stop"`
+		ArrivalStop   Stop           `json:"arrival_stop"`
+		ViaStops      []Stop         `json:"via_stop"`
+		Spaces        []RailwaySpace `json:"spaces"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*r = Railway{
+		ID:            aux.ID,
+		Name:          aux.Name,
+		Trip:          aux.Trip,
+		Type:          aux.Type,
+		Time:          aux.Time.Int(),
+		Distance:      aux.Distance.Int(),
+		DepartureStop: aux.DepartureStop,
+		ArrivalStop:   aux.ArrivalStop,
+		ViaStops:      aux.ViaStops,
+		Spaces:        aux.Spaces,
+	}
+	return nil
+}
+
+// MinFare 最低席位票价，单位：元，无票价信息时返回 0
+func (r *Railway) MinFare() float64 {
+	var fare float64
+	for _, s := range r.Spaces {
+		if s.Cost > 0 && (fare == 0 || s.Cost < fare) {
+			fare = s.Cost
+		}
+	}
+	return fare
+}
+
+// RailwaySpace 火车席位
+type RailwaySpace struct {
+	Code string  // 席位编码
+	Cost float64 // 票价，单位：元
+}
+
+// UnmarshalJSON 解析数值字段
+func (s *RailwaySpace) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Code string    `json:"code"`
+		Cost numString `json:"cost"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	*s = RailwaySpace{Code: aux.Code, Cost: aux.Cost.Float()}
+	return nil
+}
+
+// TaxiSegment 出租车路段
+type TaxiSegment struct {
+	Distance  int          // 打车距离，单位：米
+	Price     float64      // 预计费用，单位：元
+	DriveTime int          // 预计耗时，单位：秒
+	Polyline  []Coordinate // 线路坐标点
+	SName     string       // 起点名称
+	TName     string       // 终点名称
+}
+
+// UnmarshalJSON 解析数值字段与坐标串
+func (t *TaxiSegment) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Distance  numString `json:"distance"`
+		Price     numString `json:"price"`
+		DriveTime numString `json:"drivetime"`
+		Polyline  string    `json:"polyline"`
+		SName     string    `json:"sname"`
+		TName     string    `json:"tname"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	polyline, err := ParsePolyline(aux.Polyline)
+	if err != nil {
+		return err
+	}
+	*t = TaxiSegment{
+		Distance:  aux.Distance.Int(),
+		Price:     aux.Price.Float(),
+		DriveTime: aux.DriveTime.Int(),
+		Polyline:  polyline,
+		SName:     aux.SName,
+		TName:     aux.TName,
+	}
+	return nil
+}
+
+// Transit 公交路径规划 - 规划公交、地铁、火车等多种方式组合的换乘方案
+// https://lbs.amap.com/api/webservice/guide/api/direction
+func (c *Client) Transit(req *TransitRequest) (*TransitResponse, error) {
+	return c.TransitContext(context.Background(), req)
+}
+
+// TransitContext 同 Transit，支持通过 context 控制取消与超时
+func (c *Client) TransitContext(ctx context.Context, req *TransitRequest) (*TransitResponse, error) {
+	if err := checkOriginDestination(req.Origin, req.Destination); err != nil {
+		return nil, err
+	}
+	if req.City == "" {
+		return nil, fmt.Errorf("%w: city 不能为空", ErrInvalidParams)
+	}
+
+	params := url.Values{}
+	params.Set("origin", req.Origin)
+	params.Set("destination", req.Destination)
+	params.Set("city", req.City)
+
+	if req.CityD != "" {
+		params.Set("cityd", req.CityD)
+	}
+
+	if req.Strategy > 0 {
+		params.Set("strategy", strconv.Itoa(req.Strategy))
+	}
+
+	if req.NightFlag {
+		params.Set("nightflag", "1")
+	}
+
+	// 换乘方案与出发时刻相关（夜班车、末班车），零值时按当前时间显式传递，使缓存键包含出发时刻
+	departure := req.Departure
+	if departure.IsZero() {
+		departure = time.Now()
+	}
+	departure = departure.In(shanghai)
+	params.Set("date", departure.Format("2006-1-2"))
+	params.Set("time", departure.Format("15:04"))
+
+	if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	// 使用带缓存的请求，按解析后的出发日期与时刻缓存
+	body, err := c.doRequestWithCache(ctx, "direction/transit/integrated", params, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp TransitResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
@@ -0,0 +1,120 @@
+package amap
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const transitJSON = `{"status":"1","info":"OK","infocode":"10000","count":"2","route":{"origin":"116.481028,39.989643","destination":"121.473701,31.230416","distance":"1068000","taxi_cost":[],"transits":[
+{"cost":"6.0","duration":"3600","nightflag":"0","walking_distance":"700","distance":"15000","missed":"0","segments":[
+{"taxi":[],"walking":{"origin":"116.481028,39.989643","destination":"116.478,39.987","distance":"500","duration":"400","steps":[{"instruction":"步行500米","road":[],"distance":"500","duration":"400","polyline":"116.481028,39.989643;116.478,39.987","action":[],"assistant_action":"到达公交站"}]},"bus":{"buslines":[{"departure_stop":{"name":"望京","id":"BV1","location":"116.478,39.987"},"arrival_stop":{"name":"东直门","id":"BV2","location":"116.43,39.94"},"name":"地铁15号线(俸伯--清华东路西口)","id":"110100023110","type":"地铁线路","distance":"8000","duration":"1200","polyline":"116.478,39.987;116.43,39.94","start_time":"0530","end_time":"2300","via_num":"3","via_stops":[{"name":"望京东","id":"BV3","location":"116.47,39.98"}]}]},"entrance":{"name":"A口","location":"116.478,39.987"},"exit":[],"railway":{"spaces":[],"alters":[]}},
+{"taxi":[],"walking":{"distance":"200","duration":"160","steps":[]},"bus":{"buslines":[{"name":"地铁2号线","id":"110100023112","type":"地铁线路","distance":"6500","duration":"900","polyline":"","start_time":[],"end_time":[],"via_num":"2","departure_stop":{"name":"东直门"},"arrival_stop":{"name":"北京站"},"via_stops":[]}]},"entrance":[],"exit":{"name":"B口","location":"116.42,39.90"},"railway":{"spaces":[],"alters":[]}}]},
+{"cost":[],"duration":"20000","nightflag":"0","walking_distance":"300","distance":"1070000","missed":"0","segments":[
+{"taxi":{"distance":"5000","price":"20","drivetime":"900","polyline":"116.481028,39.989643;116.42,39.90","sname":"起点","tname":"北京南站"},"walking":[],"bus":{"buslines":[]},"entrance":[],"exit":[],"railway":[]},
+{"taxi":[],"walking":{"distance":"300","duration":"240","steps":[]},"bus":{"buslines":[]},"entrance":[],"exit":[],"railway":{"id":"240000G1010","time":"16000","name":"G101","trip":"G101","distance":"1060000","type":"高速动车","departure_stop":{"id":"1","name":"北京南","location":"116.37,39.86","adcode":"110106","time":"0700","start":"1"},"arrival_stop":{"id":"2","name":"上海虹桥","location":"121.32,31.19","adcode":"310112","time":"1130","end":"1"},"via_stop":[],"alters":[],"spaces":[{"code":"O","cost":"553.0"},{"code":"M","cost":"933.0"},{"code":"9","cost":[]}]}}]}]}}`
+
+func TestTransitJSONParsing(t *testing.T) {
+	var resp TransitResponse
+	if err := json.Unmarshal([]byte(transitJSON), &resp); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+	route := resp.Route
+	if route.Distance != 1068000 || route.TaxiCost != 0 || len(route.Transits) != 2 {
+		t.Fatalf("换乘信息解析错误: %+v", route)
+	}
+
+	subway := route.Transits[0]
+	if subway.Cost != 6 || subway.Duration != 3600 || len(subway.Segments) != 2 {
+		t.Errorf("地铁方案解析错误: %+v", subway)
+	}
+	line := subway.Segments[0].Bus.BusLines[0]
+	if line.Distance != 8000 || line.ViaNum != 3 || line.DepartureStop.Name != "望京" || len(line.ViaStops) != 1 || len(line.Polyline) != 2 {
+		t.Errorf("公交线路解析错误: %+v", line)
+	}
+	if subway.Segments[0].Entrance.Name != "A口" || subway.Segments[1].Exit.Name != "B口" {
+		t.Errorf("地铁出入口解析错误: %+v", subway.Segments)
+	}
+	if subway.TotalFare() != 6 || subway.TotalWalkingDistance() != 700 || subway.Transfers() != 1 {
+		t.Errorf("地铁方案汇总错误: fare=%v walk=%d transfers=%d", subway.TotalFare(), subway.TotalWalkingDistance(), subway.Transfers())
+	}
+
+	train := route.Transits[1]
+	railway := train.Segments[1].Railway
+	if railway.Trip != "G101" || railway.Distance != 1060000 || railway.ArrivalStop.Time != "1130" || railway.MinFare() != 553 {
+		t.Errorf("火车路段解析错误: %+v", railway)
+	}
+	if taxi := train.Segments[0].Taxi; taxi.Price != 20 || taxi.DriveTime != 900 || len(taxi.Polyline) != 2 {
+		t.Errorf("出租车路段解析错误: %+v", taxi)
+	}
+	if train.TotalFare() != 573 || train.TotalWalkingDistance() != 300 || train.Transfers() != 1 {
+		t.Errorf("火车方案汇总错误: fare=%v walk=%d transfers=%d", train.TotalFare(), train.TotalWalkingDistance(), train.Transfers())
+	}
+}
+
+func TestTransitRequest(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v3/direction/transit/integrated" {
+			t.Errorf("接口路径错误: %s", r.URL.Path)
+		}
+		if q.Get("city") != "北京" || q.Get("cityd") != "上海" || q.Get("nightflag") != "1" || q.Get("strategy") != "2" {
+			t.Errorf("请求参数错误: %s", r.URL.RawQuery)
+		}
+		if q.Get("date") != "2025-3-9" || q.Get("time") != "08:05" {
+			t.Errorf("出发时间参数错误: %s %s", q.Get("date"), q.Get("time"))
+		}
+		_, _ = w.Write([]byte(transitJSON))
+	})
+
+	resp, err := client.Transit(&TransitRequest{
+		Origin:      "116.481028,39.989643",
+		Destination: "121.473701,31.230416",
+		City:        "北京",
+		CityD:       "上海",
+		Strategy:    TransitStrategyLeastChange,
+		NightFlag:   true,
+		Departure:   time.Date(2025, 3, 9, 0, 5, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Route.Transits) != 2 {
+		t.Errorf("响应解析错误: %+v", resp.Route)
+	}
+
+	_, err = client.Transit(&TransitRequest{Origin: "116.481028,39.989643", Destination: "121.473701,31.230416"})
+	if !IsInvalidParams(err) {
+		t.Errorf("缺少城市应返回参数错误，实际: %v", err)
+	}
+}
+
+func TestTransitCacheByDeparture(t *testing.T) {
+	var calls int
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if q := r.URL.Query(); q.Get("date") == "" || q.Get("time") == "" {
+			t.Errorf("出发时间应显式传递: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(transitJSON))
+	})
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	req := TransitRequest{Origin: "116.481028,39.989643", Destination: "116.434446,39.90816", City: "北京"}
+	for _, departure := range []time.Time{
+		time.Date(2025, 3, 9, 8, 0, 0, 0, shanghai),
+		time.Date(2025, 3, 9, 8, 0, 30, 0, shanghai),
+		time.Date(2025, 3, 9, 23, 30, 0, 0, shanghai),
+		{},
+	} {
+		req.Departure = departure
+		if _, err := client.Transit(&req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 同一分钟出发命中缓存，不同时刻与当前时间分别请求
+	if calls != 3 {
+		t.Errorf("期望请求 3 次，实际 %d 次", calls)
+	}
+}
@@ -0,0 +1,136 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+)
+
+// 货车大小
+const (
+	TruckSizeMini   = 1 // 微型车
+	TruckSizeLight  = 2 // 轻型车
+	TruckSizeMedium = 3 // 中型车
+	TruckSizeHeavy  = 4 // 重型车
+)
+
+// TruckRequest 货车路径规划请求参数
+type TruckRequest struct {
+	Origin        string         // 起点坐标，必填 "经度,纬度"
+	Destination   string         // 终点坐标，必填 "经度,纬度"
+	Waypoints     []string       // 途经点坐标，可选，最多 16 个
+	AvoidPolygons [][]Coordinate // 避让区域，可选，最多 32 个多边形
+	Strategy      *int           // 驾车策略，可选，取值 1-10，可使用 DrivingStrategy 设置，nil 时使用接口默认策略
+	Size          int            // 货车大小，必填，见 TruckSize* 常量
+	Height        float64        // 车辆高度，单位：米，取值 0-25
+	Width         float64        // 车辆宽度，单位：米，取值 0-25
+	Load          float64        // 核定载重，单位：吨，取值 0-100
+	Weight        float64        // 车辆总重，单位：吨，取值 0-100，不小于核定载重
+	Axis          int            // 车辆轴数，取值 0-50
+	Province      string         // 车牌省份简称，可选，如 "京"
+	Number        string         // 车牌号码（不含省份），可选，用于判断限行
+	ShowPolyline  bool           // 是否返回路段坐标串
+}
+
+// Validate 校验起终点与车辆参数
+func (r *TruckRequest) Validate() error {
+	if err := checkOriginDestination(r.Origin, r.Destination); err != nil {
+		return err
+	}
+	if len(r.Waypoints) > maxWaypoints {
+		return fmt.Errorf("%w: waypoints 最多 %d 个，实际 %d 个", ErrInvalidParams, maxWaypoints, len(r.Waypoints))
+	}
+	if r.Strategy != nil && (*r.Strategy < 1 || *r.Strategy > 10) {
+		return fmt.Errorf("%w: strategy 取值 1-10，实际 %d", ErrInvalidParams, *r.Strategy)
+	}
+	if r.Size < TruckSizeMini || r.Size > TruckSizeHeavy {
+		return fmt.Errorf("%w: size 取值 1-4，实际 %d", ErrInvalidParams, r.Size)
+	}
+	for _, f := range []struct {
+		name  string
+		value float64
+		limit float64
+	}{
+		{"height", r.Height, 25},
+		{"width", r.Width, 25},
+		{"load", r.Load, 100},
+		{"weight", r.Weight, 100},
+		{"axis", float64(r.Axis), 50},
+	} {
+		if math.IsNaN(f.value) || math.IsInf(f.value, 0) || f.value < 0 || f.value > f.limit {
+			return fmt.Errorf("%w: %s 取值 0-%g，实际 %g", ErrInvalidParams, f.name, f.limit, f.value)
+		}
+	}
+	if r.Weight > 0 && r.Load > r.Weight {
+		return fmt.Errorf("%w: load(%g) 不能大于 weight(%g)", ErrInvalidParams, r.Load, r.Weight)
+	}
+	return nil
+}
+
+// Truck 货车路径规划 - 根据车辆尺寸、重量与车牌规划货车路线，规避限高、限重与限行
+// 响应与驾车路径规划相同，可复用 Path、Step 的处理逻辑
+// https://lbs.amap.com/api/webservice/guide/api/direction
+func (c *Client) Truck(req *TruckRequest) (*DrivingResponse, error) {
+	return c.TruckContext(context.Background(), req)
+}
+
+// TruckContext 同 Truck，支持通过 context 控制取消与超时
+func (c *Client) TruckContext(ctx context.Context, req *TruckRequest) (*DrivingResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("origin", req.Origin)
+	params.Set("destination", req.Destination)
+	params.Set("size", strconv.Itoa(req.Size))
+	setAvoidance(params, req.Waypoints, req.AvoidPolygons)
+
+	if req.Strategy != nil {
+		params.Set("strategy", strconv.Itoa(*req.Strategy))
+	}
+
+	for name, value := range map[string]float64{
+		"height": req.Height,
+		"width":  req.Width,
+		"load":   req.Load,
+		"weight": req.Weight,
+	} {
+		if value > 0 {
+			params.Set(name, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+
+	if req.Axis > 0 {
+		params.Set("axis", strconv.Itoa(req.Axis))
+	}
+
+	if req.Number != "" {
+		params.Set("province", req.Province)
+		params.Set("number", req.Number)
+	}
+
+	if req.ShowPolyline {
+		params.Set("showpolyline", "1")
+	}
+
+	// 使用带缓存的请求
+	body, err := c.doRequestWithCache(ctx, "v4/direction/truck", params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp DrivingResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
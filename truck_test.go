@@ -0,0 +1,89 @@
+package amap
+
+import (
+	"math"
+	"net/http"
+	"testing"
+)
+
+const truckJSON = `{"data":{"route":{"origin":"116.481028,39.989643","destination":"116.434446,39.90816","paths":[{"distance":12800,"duration":2100,"strategy":"速度优先","tolls":10,"toll_distance":3000,"restriction":0,"traffic_lights":8,"steps":[{"instruction":"向西南行驶179米右转","orientation":"西南","road":"阜通东大街","distance":179,"duration":40,"tolls":0,"toll_distance":0,"toll_road":"","polyline":"116.481028,39.989643;116.480286,39.988992","action":"右转","assistant_action":""}]}]},"count":1},"errcode":0,"errdetail":null,"errmsg":"OK"}`
+
+func TestTruckValidate(t *testing.T) {
+	valid := TruckRequest{
+		Origin:      "116.481028,39.989643",
+		Destination: "116.434446,39.90816",
+		Size:        TruckSizeMedium,
+		Height:      4.2,
+		Width:       2.5,
+		Load:        10,
+		Weight:      18,
+		Axis:        3,
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("合法参数校验失败: %v", err)
+	}
+
+	tests := map[string]func(r *TruckRequest){
+		"缺少起点":   func(r *TruckRequest) { r.Origin = "" },
+		"缺少大小":   func(r *TruckRequest) { r.Size = 0 },
+		"高度超限":   func(r *TruckRequest) { r.Height = 30 },
+		"宽度为负":   func(r *TruckRequest) { r.Width = -1 },
+		"总重超限":   func(r *TruckRequest) { r.Weight = 120 },
+		"高度为NaN": func(r *TruckRequest) { r.Height = math.NaN() },
+		"载重为Inf": func(r *TruckRequest) { r.Load = math.Inf(1) },
+		"轴数超限":   func(r *TruckRequest) { r.Axis = 60 },
+		"载重大于总重": func(r *TruckRequest) { r.Load = 20 },
+		"途经点过多":  func(r *TruckRequest) { r.Waypoints = make([]string, 17) },
+		"策略超限":   func(r *TruckRequest) { r.Strategy = DrivingStrategy(0) },
+	}
+	for name, modify := range tests {
+		req := valid
+		modify(&req)
+		if err := req.Validate(); !IsInvalidParams(err) {
+			t.Errorf("%s: 应返回参数错误，实际: %v", name, err)
+		}
+	}
+}
+
+func TestTruckRequest(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/v4/direction/truck" {
+			t.Errorf("接口路径错误: %s", r.URL.Path)
+		}
+		if q.Get("size") != "3" || q.Get("height") != "4.2" || q.Get("weight") != "18" || q.Get("axis") != "3" || q.Get("number") != "A12345" || q.Get("showpolyline") != "1" || q.Get("strategy") != "5" {
+			t.Errorf("请求参数错误: %s", r.URL.RawQuery)
+		}
+		if q.Get("width") != "" {
+			t.Errorf("未设置的参数不应发送: %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(truckJSON))
+	})
+
+	resp, err := client.Truck(&TruckRequest{
+		Origin:       "116.481028,39.989643",
+		Destination:  "116.434446,39.90816",
+		Size:         TruckSizeMedium,
+		Height:       4.2,
+		Weight:       18,
+		Axis:         3,
+		Strategy:     DrivingStrategy(5),
+		Province:     "京",
+		Number:       "A12345",
+		ShowPolyline: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Count != "1" || len(resp.Route.Paths) != 1 {
+		t.Fatalf("响应解析错误: %+v", resp)
+	}
+	path := resp.Route.Paths[0]
+	if path.Distance != 12800 || path.Tolls != 10 || path.TrafficLights != 8 || len(path.Steps[0].Polyline) != 2 {
+		t.Errorf("方案解析错误: %+v", path)
+	}
+
+	if _, err := client.Truck(&TruckRequest{Origin: "116.481028,39.989643", Destination: "116.434446,39.90816"}); !IsInvalidParams(err) {
+		t.Errorf("缺少货车大小应返回参数错误，实际: %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package amap
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// WalkingRequest 步行路径规划请求参数
+type WalkingRequest struct {
+	Origin           string   // 起点坐标，必填 "经度,纬度"
+	Destination      string   // 终点坐标，必填 "经度,纬度"
+	AlternativeRoute int      // 返回方案条数，可选，取值 1-3，设置后使用 v5 接口
+	ShowFields       []string // 扩展字段，设置后使用 v5 接口，见 ShowField* 常量
+}
+
+// Walking 步行路径规划 - 规划 100 公里以内的步行路线
+// 设置 AlternativeRoute 或 ShowFields 时使用 v5 接口，否则使用 v3 接口
+// https://lbs.amap.com/api/webservice/guide/api/direction
+func (c *Client) Walking(req *WalkingRequest) (*RouteResponse, error) {
+	return c.WalkingContext(context.Background(), req)
+}
+
+// WalkingContext 同 Walking，支持通过 context 控制取消与超时
+func (c *Client) WalkingContext(ctx context.Context, req *WalkingRequest) (*RouteResponse, error) {
+	if err := checkOriginDestination(req.Origin, req.Destination); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("origin", req.Origin)
+	params.Set("destination", req.Destination)
+
+	if req.AlternativeRoute <= 0 && len(req.ShowFields) == 0 {
+		return c.doRouteRequest(ctx, "direction/walking", params, req)
+	}
+
+	if req.AlternativeRoute > 0 {
+		params.Set("alternative_route", strconv.Itoa(req.AlternativeRoute))
+	}
+	if len(req.ShowFields) > 0 {
+		params.Set("show_fields", strings.Join(req.ShowFields, ","))
+	}
+
+	return c.doRouteRequest(ctx, "v5/direction/walking", params, req)
+}
@@ -0,0 +1,246 @@
+package amap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 天气查询类型
+const (
+	WeatherLive     = "base" // 实况天气
+	WeatherForecast = "all"  // 预报天气
+)
+
+// WeatherRequest 天气查询请求参数
+type WeatherRequest struct {
+	City       string // 城市的 adcode，必填，可使用地理编码、逆地理编码或IP定位返回的 adcode
+	Extensions string // 气象类型：WeatherLive(默认) 或 WeatherForecast
+}
+
+// WeatherResponse 天气查询响应
+type WeatherResponse struct {
+	BaseResponse
+	Count     string        `json:"count"`     // 返回结果总数
+	Lives     []LiveWeather `json:"lives"`     // 实况天气，WeatherLive 时返回
+	Forecasts []Forecast    `json:"forecasts"` // 预报天气，WeatherForecast 时返回
+}
+
+// LiveWeather 实况天气
+type LiveWeather struct {
+	Province      string    // 省份名
+	City          string    // 城市名
+	AdCode        string    // 区域编码
+	Weather       string    // 天气现象（汉字描述）
+	Temperature   float64   // 实时气温，单位：摄氏度
+	WindDirection string    // 风向描述
+	WindPower     string    // 风力级别，如 "≤3"
+	Humidity      float64   // 空气湿度，单位：%
+	ReportTime    time.Time // 数据发布时间，北京时间
+}
+
+// UnmarshalJSON 解析温度、湿度与发布时间
+func (w *LiveWeather) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Province         string `json:"province"`
+		City             string `json:"city"`
+		AdCode           string `json:"adcode"`
+		Weather          string `json:"weather"`
+		Temperature      string `json:"temperature"`
+		TemperatureFloat string `json:"temperature_float"`
+		WindDirection    string `json:"winddirection"`
+		WindPower        string `json:"windpower"`
+		Humidity         string `json:"humidity"`
+		HumidityFloat    string `json:"humidity_float"`
+		ReportTime       string `json:"reporttime"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	reportTime, err := parseReportTime(aux.ReportTime)
+	if err != nil {
+		return err
+	}
+	*w = LiveWeather{
+		Province:      aux.Province,
+		City:          aux.City,
+		AdCode:        aux.AdCode,
+		Weather:       aux.Weather,
+		Temperature:   parseFloat(aux.TemperatureFloat, aux.Temperature),
+		WindDirection: aux.WindDirection,
+		WindPower:     aux.WindPower,
+		Humidity:      parseFloat(aux.HumidityFloat, aux.Humidity),
+		ReportTime:    reportTime,
+	}
+	return nil
+}
+
+// Forecast 预报天气
+type Forecast struct {
+	Province   string    // 省份名
+	City       string    // 城市名
+	AdCode     string    // 区域编码
+	ReportTime time.Time // 预报发布时间，北京时间
+	Casts      []Cast    // 预报数据，包含当天在内的 4 天
+}
+
+// UnmarshalJSON 解析发布时间
+func (f *Forecast) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Province   string `json:"province"`
+		City       string `json:"city"`
+		AdCode     string `json:"adcode"`
+		ReportTime string `json:"reporttime"`
+		Casts      []Cast `json:"casts"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	reportTime, err := parseReportTime(aux.ReportTime)
+	if err != nil {
+		return err
+	}
+	*f = Forecast{
+		Province:   aux.Province,
+		City:       aux.City,
+		AdCode:     aux.AdCode,
+		ReportTime: reportTime,
+		Casts:      aux.Casts,
+	}
+	return nil
+}
+
+// Cast 单日预报
+type Cast struct {
+	Date         time.Time // 日期，北京时间零点
+	Week         int       // 星期几，1-7
+	DayWeather   string    // 白天天气现象
+	NightWeather string    // 晚上天气现象
+	DayTemp      float64   // 白天温度，单位：摄氏度
+	NightTemp    float64   // 晚上温度，单位：摄氏度
+	DayWind      string    // 白天风向
+	NightWind    string    // 晚上风向
+	DayPower     string    // 白天风力
+	NightPower   string    // 晚上风力
+}
+
+// UnmarshalJSON 解析日期与温度
+func (c *Cast) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Date           string `json:"date"`
+		Week           string `json:"week"`
+		DayWeather     string `json:"dayweather"`
+		NightWeather   string `json:"nightweather"`
+		DayTemp        string `json:"daytemp"`
+		NightTemp      string `json:"nighttemp"`
+		DayWind        string `json:"daywind"`
+		NightWind      string `json:"nightwind"`
+		DayPower       string `json:"daypower"`
+		NightPower     string `json:"nightpower"`
+		DayTempFloat   string `json:"daytemp_float"`
+		NightTempFloat string `json:"nighttemp_float"`
+	}
+	if err := unmarshalLenient(data, &aux); err != nil {
+		return err
+	}
+	var date time.Time
+	if aux.Date != "" {
+		var err error
+		if date, err = time.ParseInLocation(time.DateOnly, aux.Date, shanghai); err != nil {
+			return err
+		}
+	}
+	week, _ := strconv.Atoi(aux.Week)
+	*c = Cast{
+		Date:         date,
+		Week:         week,
+		DayWeather:   aux.DayWeather,
+		NightWeather: aux.NightWeather,
+		DayTemp:      parseFloat(aux.DayTempFloat, aux.DayTemp),
+		NightTemp:    parseFloat(aux.NightTempFloat, aux.NightTemp),
+		DayWind:      aux.DayWind,
+		NightWind:    aux.NightWind,
+		DayPower:     aux.DayPower,
+		NightPower:   aux.NightPower,
+	}
+	return nil
+}
+
+// parseReportTime 解析北京时间格式的发布时间
+func parseReportTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(time.DateTime, s, shanghai)
+}
+
+// parseFloat 依次尝试解析，返回第一个成功的值
+func parseFloat(values ...string) float64 {
+	for _, v := range values {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return 0
+}
+
+// Weather 天气查询 - 根据 adcode 查询实况或预报天气
+// https://lbs.amap.com/api/webservice/guide/api/weatherinfo
+func (c *Client) Weather(req *WeatherRequest) (*WeatherResponse, error) {
+	return c.WeatherContext(context.Background(), req)
+}
+
+// WeatherContext 同 Weather，支持通过 context 控制取消与超时
+func (c *Client) WeatherContext(ctx context.Context, req *WeatherRequest) (*WeatherResponse, error) {
+	if req.City == "" {
+		return nil, fmt.Errorf("%w: city 不能为空", ErrInvalidParams)
+	}
+
+	params := url.Values{}
+	params.Set("city", req.City)
+
+	if req.Extensions != "" {
+		params.Set("extensions", req.Extensions)
+	}
+
+	// 使用带缓存的请求
+	body, err := c.doRequestWithCache(ctx, "weather/weatherInfo", params, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp WeatherResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	if err := resp.GetError(); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+// WeatherByLocation 根据坐标查询天气，先逆地理编码获取 adcode 再查询天气，两步均使用缓存
+// location 为 "经度,纬度"，extensions 为 WeatherLive 或 WeatherForecast
+func (c *Client) WeatherByLocation(location, extensions string) (*WeatherResponse, error) {
+	return c.WeatherByLocationContext(context.Background(), location, extensions)
+}
+
+// WeatherByLocationContext 同 WeatherByLocation，支持通过 context 控制取消与超时
+func (c *Client) WeatherByLocationContext(ctx context.Context, location, extensions string) (*WeatherResponse, error) {
+	regeo, err := c.RegeoContext(ctx, &RegeoRequest{Location: location})
+	if err != nil {
+		return nil, err
+	}
+
+	adcode := regeo.Regeocode.AddressComponent.AdCode
+	if adcode == "" {
+		return nil, fmt.Errorf("%w: 坐标 %s 不在任何行政区内", ErrInvalidParams, location)
+	}
+
+	return c.WeatherContext(ctx, &WeatherRequest{City: adcode, Extensions: extensions})
+}
@@ -0,0 +1,82 @@
+package amap
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	liveWeatherJSON     = `{"status":"1","count":"1","info":"OK","infocode":"10000","lives":[{"province":"北京","city":"朝阳区","adcode":"110105","weather":"晴","temperature":"23","winddirection":"西南","windpower":"≤3","humidity":"56","reporttime":"2025-05-01 10:02:33","temperature_float":"23.5","humidity_float":"56.0"}]}`
+	forecastWeatherJSON = `{"status":"1","count":"1","info":"OK","infocode":"10000","forecasts":[{"city":"朝阳区","adcode":"110105","province":"北京","reporttime":"2025-05-01 10:02:33","casts":[{"date":"2025-05-01","week":"4","dayweather":"晴","nightweather":"多云","daytemp":"28","nighttemp":"14","daywind":"南","nightwind":"南","daypower":"1-3","nightpower":"1-3","daytemp_float":"28.0","nighttemp_float":"14.0"}]}]}`
+)
+
+func TestWeatherJSONParsing(t *testing.T) {
+	var live WeatherResponse
+	if err := json.Unmarshal([]byte(liveWeatherJSON), &live); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+	w := live.Lives[0]
+	if w.Temperature != 23.5 || w.Humidity != 56 || w.WindPower != "≤3" {
+		t.Errorf("实况天气解析错误: %+v", w)
+	}
+	if want := time.Date(2025, 5, 1, 2, 2, 33, 0, time.UTC); !w.ReportTime.Equal(want) {
+		t.Errorf("发布时间解析错误: %v", w.ReportTime)
+	}
+
+	var forecast WeatherResponse
+	if err := json.Unmarshal([]byte(forecastWeatherJSON), &forecast); err != nil {
+		t.Fatalf("JSON解析失败: %v", err)
+	}
+	cast := forecast.Forecasts[0].Casts[0]
+	if cast.DayTemp != 28 || cast.NightTemp != 14 || cast.Week != 4 {
+		t.Errorf("预报天气解析错误: %+v", cast)
+	}
+	if cast.Date.Format(time.DateOnly) != "2025-05-01" || cast.Date.Location().String() != "Asia/Shanghai" {
+		t.Errorf("日期解析错误: %v", cast.Date)
+	}
+}
+
+func TestWeatherByLocation(t *testing.T) {
+	calls := make(map[string]int)
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls[r.URL.Path]++
+		switch r.URL.Path {
+		case "/v3/geocode/regeo":
+			_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","regeocode":{"formatted_address":"北京市朝阳区望京街道","addressComponent":{"country":"中国","province":"北京市","city":[],"citycode":"010","district":"朝阳区","adcode":"110105","township":"望京街道","towncode":"110105026000","neighborhood":{"name":[],"type":[]},"building":{"name":[],"type":[]},"streetNumber":{"street":"阜通东大街","number":"6号","location":"116.482086,39.990496","direction":"东北","distance":"63.2"},"seaArea":[],"businessAreas":[[]]}}}`))
+		case "/v3/weather/weatherInfo":
+			if q := r.URL.Query(); q.Get("city") != "110105" || q.Get("extensions") != WeatherForecast {
+				t.Errorf("请求参数错误: %s", r.URL.String())
+			}
+			_, _ = w.Write([]byte(forecastWeatherJSON))
+		}
+	})
+	client.SetCache(NewTTLMapCache(time.Minute))
+
+	for range 2 {
+		resp, err := client.WeatherByLocation("116.481488,39.990464", WeatherForecast)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Forecasts) != 1 || resp.Forecasts[0].AdCode != "110105" {
+			t.Fatalf("结果错误: %+v", resp.Forecasts)
+		}
+	}
+	if calls["/v3/geocode/regeo"] != 1 || calls["/v3/weather/weatherInfo"] != 1 {
+		t.Errorf("期望两步均命中缓存，实际请求: %v", calls)
+	}
+}
+
+func TestWeatherByLocationSea(t *testing.T) {
+	client := newMockClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/geocode/regeo" {
+			t.Errorf("海域坐标不应查询天气: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"status":"1","info":"OK","infocode":"10000","regeocode":{"formatted_address":[],"addressComponent":{"country":[],"province":[],"city":[],"citycode":[],"district":[],"adcode":[],"township":[],"towncode":[],"neighborhood":{"name":[],"type":[]},"building":{"name":[],"type":[]},"streetNumber":{"street":[],"number":[],"direction":[],"distance":[]},"seaArea":[],"businessAreas":[]},"pois":[],"roads":[],"roadinters":[],"aois":[]}}`))
+	})
+
+	if _, err := client.WeatherByLocation("150.0,20.0", WeatherLive); !IsInvalidParams(err) {
+		t.Errorf("海域坐标应返回参数错误，实际: %v", err)
+	}
+}